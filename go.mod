@@ -7,6 +7,7 @@ require (
 	github.com/google/uuid v1.6.0
 	github.com/scjalliance/comshim v0.0.0-20260808212102-9fa4e0fd1400
 	github.com/scjalliance/comutil v0.0.0-20260808212255-b48931396468
+	golang.org/x/text v0.40.0
 )
 
 require golang.org/x/sys v0.47.0 // indirect
@@ -0,0 +1,39 @@
+// Package filetime converts between Windows FILETIME values and time.Time.
+//
+// https://docs.microsoft.com/en-us/windows/win32/api/minwinbase/ns-minwinbase-filetime
+package filetime
+
+import "time"
+
+const (
+	// ticksPerSecond is the number of 100-nanosecond intervals in a second.
+	ticksPerSecond = 10000000
+
+	// epochDelta is the number of seconds between the FILETIME epoch
+	// (January 1, 1601 UTC) and the Unix epoch.
+	epochDelta = 11644473600
+)
+
+// ToTime converts a FILETIME value to a time.Time in UTC. A value of zero
+// is converted to the zero time.
+func ToTime(ft uint64) time.Time {
+	if ft == 0 {
+		return time.Time{}
+	}
+	secs := int64(ft/ticksPerSecond) - epochDelta
+	nsecs := int64(ft%ticksPerSecond) * 100
+	return time.Unix(secs, nsecs).UTC()
+}
+
+// FromTime converts t to a FILETIME value. The zero time, and any time
+// before the FILETIME epoch, is converted to zero.
+func FromTime(t time.Time) uint64 {
+	if t.IsZero() {
+		return 0
+	}
+	secs := t.Unix() + epochDelta
+	if secs < 0 {
+		return 0
+	}
+	return uint64(secs)*ticksPerSecond + uint64(t.Nanosecond()/100)
+}
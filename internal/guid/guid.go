@@ -0,0 +1,42 @@
+// Package guid converts between uuid values and the mixed-endian GUID
+// layout used by Windows binary formats.
+package guid
+
+import (
+	"encoding/binary"
+
+	"github.com/google/uuid"
+)
+
+// Size is the number of bytes in a binary GUID.
+const Size = 16
+
+// Decode interprets the first 16 bytes of b as a GUID in its Windows
+// in-memory layout and returns it as a uuid.
+//
+// The first three fields of a Windows GUID are stored in little-endian
+// byte order, whereas a uuid stores all of its bytes in big-endian order.
+func Decode(b []byte) uuid.UUID {
+	var u uuid.UUID
+	binary.BigEndian.PutUint32(u[0:4], binary.LittleEndian.Uint32(b[0:4]))
+	binary.BigEndian.PutUint16(u[4:6], binary.LittleEndian.Uint16(b[4:6]))
+	binary.BigEndian.PutUint16(u[6:8], binary.LittleEndian.Uint16(b[6:8]))
+	copy(u[8:16], b[8:16])
+	return u
+}
+
+// Put writes u to the first 16 bytes of b in the Windows in-memory GUID
+// layout.
+func Put(b []byte, u uuid.UUID) {
+	binary.LittleEndian.PutUint32(b[0:4], binary.BigEndian.Uint32(u[0:4]))
+	binary.LittleEndian.PutUint16(b[4:6], binary.BigEndian.Uint16(u[4:6]))
+	binary.LittleEndian.PutUint16(b[6:8], binary.BigEndian.Uint16(u[6:8]))
+	copy(b[8:16], u[8:16])
+}
+
+// Bytes returns the Windows in-memory GUID layout of u.
+func Bytes(u uuid.UUID) []byte {
+	b := make([]byte, Size)
+	Put(b, u)
+	return b
+}
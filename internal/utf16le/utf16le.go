@@ -0,0 +1,54 @@
+// Package utf16le encodes and decodes little-endian UTF-16 strings as they
+// appear in Windows binary formats.
+package utf16le
+
+import (
+	"encoding/binary"
+	"unicode/utf16"
+)
+
+// Encode returns the UTF-16LE encoding of s without a null terminator.
+func Encode(s string) []byte {
+	units := utf16.Encode([]rune(s))
+	b := make([]byte, len(units)*2)
+	for i, u := range units {
+		binary.LittleEndian.PutUint16(b[i*2:], u)
+	}
+	return b
+}
+
+// EncodeZ returns the UTF-16LE encoding of s followed by a null terminator.
+func EncodeZ(s string) []byte {
+	return append(Encode(s), 0, 0)
+}
+
+// Decode interprets b as a sequence of UTF-16LE code units and returns
+// the string they represent. A trailing odd byte is ignored.
+func Decode(b []byte) string {
+	units := make([]uint16, len(b)/2)
+	for i := range units {
+		units[i] = binary.LittleEndian.Uint16(b[i*2:])
+	}
+	return string(utf16.Decode(units))
+}
+
+// DecodeZ decodes a null-terminated UTF-16LE string from the start of b.
+// It returns the string and the number of bytes consumed, including the
+// terminator. If b does not contain a terminator, ok will be false.
+func DecodeZ(b []byte) (s string, n int, ok bool) {
+	for i := 0; i+1 < len(b); i += 2 {
+		if b[i] == 0 && b[i+1] == 0 {
+			return Decode(b[:i]), i + 2, true
+		}
+	}
+	return "", 0, false
+}
+
+// Len returns the number of UTF-16 code units needed to encode s.
+func Len(s string) int {
+	n := 0
+	for _, r := range s {
+		n += utf16.RuneLen(r)
+	}
+	return n
+}
@@ -0,0 +1,75 @@
+package propkey
+
+import "strings"
+
+// entry associates a property key with its canonical name.
+type entry struct {
+	name string
+	key  PropertyKey
+}
+
+// catalog lists the well-known property keys and their canonical names.
+var catalog = []entry{
+	{"System.Title", Title},
+	{"System.Subject", Subject},
+	{"System.Author", Author},
+	{"System.Keywords", Keywords},
+	{"System.Comment", Comment},
+	{"System.ItemTypeText", ItemTypeText},
+	{"System.ItemNameDisplay", ItemNameDisplay},
+	{"System.Size", Size},
+	{"System.FileAttributes", FileAttributes},
+	{"System.DateModified", DateModified},
+	{"System.DateCreated", DateCreated},
+	{"System.DateAccessed", DateAccessed},
+	{"System.FileName", FileName},
+	{"System.DescriptionID", DescriptionID},
+	{"System.ItemType", ItemType},
+	{"System.ParsingName", ParsingName},
+	{"System.ParsingPath", ParsingPath},
+	{"System.ItemFolderPathDisplay", ItemFolderPathDisplay},
+	{"System.ItemPathDisplay", ItemPathDisplay},
+	{"System.AppUserModel.RelaunchCommand", AppUserModelRelaunchCommand},
+	{"System.AppUserModel.RelaunchIconResource", AppUserModelRelaunchIconResource},
+	{"System.AppUserModel.RelaunchDisplayNameResource", AppUserModelRelaunchDisplayNameResource},
+	{"System.AppUserModel.ID", AppUserModelID},
+	{"System.AppUserModel.IsDestListSeparator", AppUserModelIsDestListSeparator},
+	{"System.AppUserModel.IsDestListLink", AppUserModelIsDestListLink},
+	{"System.AppUserModel.ExcludeFromShowInNewInstall", AppUserModelExcludeFromShowInNewInstall},
+	{"System.AppUserModel.PreventPinning", AppUserModelPreventPinning},
+	{"System.AppUserModel.BestShortcut", AppUserModelBestShortcut},
+	{"System.AppUserModel.IsDualMode", AppUserModelIsDualMode},
+	{"System.AppUserModel.StartPinOption", AppUserModelStartPinOption},
+	{"System.AppUserModel.ToastActivatorCLSID", AppUserModelToastActivatorCLSID},
+	{"System.Link.TargetParsingPath", LinkTargetParsingPath},
+	{"System.Link.Status", LinkStatus},
+	{"System.Link.Comment", LinkComment},
+	{"System.Link.TargetSFGAOFlags", LinkTargetSFGAOFlags},
+	{"System.Link.Arguments", LinkArguments},
+	{"System.Link.TargetExtension", LinkTargetExtension},
+	{"System.Link.TargetUrl", LinkTargetURL},
+	{"System.Link.DateVisited", LinkDateVisited},
+}
+
+// names and keys index the catalog by key and by lower-case name.
+var (
+	names = make(map[PropertyKey]string, len(catalog))
+	keys  = make(map[string]PropertyKey, len(catalog))
+)
+
+func init() {
+	for _, e := range catalog {
+		names[e.key] = e.name
+		keys[strings.ToLower(e.name)] = e.key
+	}
+}
+
+// Names returns the canonical names of all property keys in the catalog,
+// in a stable order.
+func Names() []string {
+	list := make([]string, len(catalog))
+	for i, e := range catalog {
+		list[i] = e.name
+	}
+	return list
+}
@@ -0,0 +1,221 @@
+package propkey
+
+import (
+	"github.com/google/uuid"
+)
+
+// Format identifiers shared by groups of well-known properties.
+var (
+	// summaryInformation is the format identifier of the document summary
+	// information properties (FMTID_SummaryInformation).
+	//
+	//	{F29F85E0-4FF9-1068-AB91-08002B27B3D9}
+	summaryInformation = uuid.UUID{0xF2, 0x9F, 0x85, 0xE0, 0x4F, 0xF9, 0x10, 0x68, 0xAB, 0x91, 0x08, 0x00, 0x2B, 0x27, 0xB3, 0xD9}
+
+	// storage is the format identifier of the file system storage
+	// properties (FMTID_Storage).
+	//
+	//	{B725F130-47EF-101A-A5F1-02608C9EEBAC}
+	storage = uuid.UUID{0xB7, 0x25, 0xF1, 0x30, 0x47, 0xEF, 0x10, 0x1A, 0xA5, 0xF1, 0x02, 0x60, 0x8C, 0x9E, 0xEB, 0xAC}
+
+	// shellDetails is the format identifier of the shell folder details
+	// properties (FMTID_ShellDetails).
+	//
+	//	{28636AA6-953D-11D2-B5D6-00C04FD918D0}
+	shellDetails = uuid.UUID{0x28, 0x63, 0x6A, 0xA6, 0x95, 0x3D, 0x11, 0xD2, 0xB5, 0xD6, 0x00, 0xC0, 0x4F, 0xD9, 0x18, 0xD0}
+
+	// itemPath is the format identifier of the item path display
+	// properties.
+	//
+	//	{E3E0584C-B788-4A5A-BB20-7F5A44C9ACDD}
+	itemPath = uuid.UUID{0xE3, 0xE0, 0x58, 0x4C, 0xB7, 0x88, 0x4A, 0x5A, 0xBB, 0x20, 0x7F, 0x5A, 0x44, 0xC9, 0xAC, 0xDD}
+
+	// appUserModel is the format identifier of the application user model
+	// properties.
+	//
+	//	{9F4C2855-9F79-4B39-A8D0-E1D42DE1D5F3}
+	appUserModel = uuid.UUID{0x9F, 0x4C, 0x28, 0x55, 0x9F, 0x79, 0x4B, 0x39, 0xA8, 0xD0, 0xE1, 0xD4, 0x2D, 0xE1, 0xD5, 0xF3}
+
+	// link is the format identifier of the shell link target properties.
+	//
+	//	{B9B4B3FC-2B51-4A42-B5D8-324146AFCF25}
+	link = uuid.UUID{0xB9, 0xB4, 0xB3, 0xFC, 0x2B, 0x51, 0x4A, 0x42, 0xB5, 0xD8, 0x32, 0x41, 0x46, 0xAF, 0xCF, 0x25}
+
+	// internetShortcut is the format identifier of the internet shortcut
+	// properties (FMTID_InternetSite).
+	//
+	//	{5CBF2787-48CF-4208-B90E-EE5E5D420294}
+	internetShortcut = uuid.UUID{0x5C, 0xBF, 0x27, 0x87, 0x48, 0xCF, 0x42, 0x08, 0xB9, 0x0E, 0xEE, 0x5E, 0x5D, 0x42, 0x02, 0x94}
+)
+
+// Document properties.
+var (
+	// Title is the title of an item (System.Title).
+	Title = PropertyKey{FormatID: summaryInformation, PID: 2}
+
+	// Subject is the subject of an item (System.Subject).
+	Subject = PropertyKey{FormatID: summaryInformation, PID: 3}
+
+	// Author is the list of authors of an item (System.Author).
+	Author = PropertyKey{FormatID: summaryInformation, PID: 4}
+
+	// Keywords is the list of keywords of an item (System.Keywords).
+	Keywords = PropertyKey{FormatID: summaryInformation, PID: 5}
+
+	// Comment is the comment of an item (System.Comment).
+	Comment = PropertyKey{FormatID: summaryInformation, PID: 6}
+)
+
+// Item and file system properties.
+var (
+	// ItemTypeText is the user-friendly description of an item's type
+	// (System.ItemTypeText).
+	ItemTypeText = PropertyKey{FormatID: storage, PID: 4}
+
+	// ItemNameDisplay is the display name of an item
+	// (System.ItemNameDisplay).
+	ItemNameDisplay = PropertyKey{FormatID: storage, PID: 10}
+
+	// Size is the size of an item in bytes (System.Size).
+	Size = PropertyKey{FormatID: storage, PID: 12}
+
+	// FileAttributes is the file attributes of an item
+	// (System.FileAttributes).
+	FileAttributes = PropertyKey{FormatID: storage, PID: 13}
+
+	// DateModified is the time an item was last modified
+	// (System.DateModified).
+	DateModified = PropertyKey{FormatID: storage, PID: 14}
+
+	// DateCreated is the time an item was created (System.DateCreated).
+	DateCreated = PropertyKey{FormatID: storage, PID: 15}
+
+	// DateAccessed is the time an item was last accessed
+	// (System.DateAccessed).
+	DateAccessed = PropertyKey{FormatID: storage, PID: 16}
+
+	// FileName is the file name of an item, including its extension
+	// (System.FileName).
+	//
+	//	{41CF5AE0-F75A-4806-BD87-59C7D9248EB9} 100
+	FileName = PropertyKey{FormatID: uuid.UUID{0x41, 0xCF, 0x5A, 0xE0, 0xF7, 0x5A, 0x48, 0x06, 0xBD, 0x87, 0x59, 0xC7, 0xD9, 0x24, 0x8E, 0xB9}, PID: 100}
+
+	// DescriptionID is the shell description of an item
+	// (System.DescriptionID).
+	DescriptionID = PropertyKey{FormatID: shellDetails, PID: 2}
+
+	// ItemType is the canonical type of an item, such as its file
+	// extension (System.ItemType).
+	ItemType = PropertyKey{FormatID: shellDetails, PID: 11}
+
+	// ParsingName is the parsing name of an item relative to its parent
+	// folder (System.ParsingName).
+	ParsingName = PropertyKey{FormatID: shellDetails, PID: 24}
+
+	// ParsingPath is the full parsing path of an item
+	// (System.ParsingPath).
+	ParsingPath = PropertyKey{FormatID: shellDetails, PID: 30}
+
+	// ItemFolderPathDisplay is the display path of the folder that holds
+	// an item (System.ItemFolderPathDisplay).
+	ItemFolderPathDisplay = PropertyKey{FormatID: itemPath, PID: 6}
+
+	// ItemPathDisplay is the display path of an item
+	// (System.ItemPathDisplay).
+	ItemPathDisplay = PropertyKey{FormatID: itemPath, PID: 7}
+)
+
+// Application user model properties.
+var (
+	// AppUserModelRelaunchCommand is the command used to relaunch an
+	// application from its taskbar button
+	// (System.AppUserModel.RelaunchCommand).
+	AppUserModelRelaunchCommand = PropertyKey{FormatID: appUserModel, PID: 2}
+
+	// AppUserModelRelaunchIconResource is the icon shown on an
+	// application's taskbar button when it is pinned
+	// (System.AppUserModel.RelaunchIconResource).
+	AppUserModelRelaunchIconResource = PropertyKey{FormatID: appUserModel, PID: 3}
+
+	// AppUserModelRelaunchDisplayNameResource is the name shown on an
+	// application's taskbar button when it is pinned
+	// (System.AppUserModel.RelaunchDisplayNameResource).
+	AppUserModelRelaunchDisplayNameResource = PropertyKey{FormatID: appUserModel, PID: 4}
+
+	// AppUserModelID is the application user model ID, which associates
+	// a window or shortcut with an application's taskbar button
+	// (System.AppUserModel.ID).
+	AppUserModelID = PropertyKey{FormatID: appUserModel, PID: 5}
+
+	// AppUserModelIsDestListSeparator indicates that a jump list item is
+	// a separator (System.AppUserModel.IsDestListSeparator).
+	AppUserModelIsDestListSeparator = PropertyKey{FormatID: appUserModel, PID: 6}
+
+	// AppUserModelIsDestListLink indicates that a jump list item is a link
+	// (System.AppUserModel.IsDestListLink).
+	AppUserModelIsDestListLink = PropertyKey{FormatID: appUserModel, PID: 7}
+
+	// AppUserModelExcludeFromShowInNewInstall prevents a shortcut from
+	// being highlighted as newly installed
+	// (System.AppUserModel.ExcludeFromShowInNewInstall).
+	AppUserModelExcludeFromShowInNewInstall = PropertyKey{FormatID: appUserModel, PID: 8}
+
+	// AppUserModelPreventPinning prevents an application from being pinned
+	// to the taskbar or start menu (System.AppUserModel.PreventPinning).
+	AppUserModelPreventPinning = PropertyKey{FormatID: appUserModel, PID: 9}
+
+	// AppUserModelBestShortcut indicates the preferred shortcut of an
+	// application (System.AppUserModel.BestShortcut).
+	AppUserModelBestShortcut = PropertyKey{FormatID: appUserModel, PID: 10}
+
+	// AppUserModelIsDualMode indicates that an application runs in both
+	// desktop and immersive modes (System.AppUserModel.IsDualMode).
+	AppUserModelIsDualMode = PropertyKey{FormatID: appUserModel, PID: 11}
+
+	// AppUserModelStartPinOption controls whether a shortcut is pinned to
+	// the start menu when it is installed
+	// (System.AppUserModel.StartPinOption).
+	AppUserModelStartPinOption = PropertyKey{FormatID: appUserModel, PID: 12}
+
+	// AppUserModelToastActivatorCLSID is the class identifier of the COM
+	// server that is activated when a user interacts with an application's
+	// notifications (System.AppUserModel.ToastActivatorCLSID).
+	AppUserModelToastActivatorCLSID = PropertyKey{FormatID: appUserModel, PID: 26}
+)
+
+// Shell link properties.
+var (
+	// LinkTargetParsingPath is the parsing path of a link's target
+	// (System.Link.TargetParsingPath).
+	LinkTargetParsingPath = PropertyKey{FormatID: link, PID: 2}
+
+	// LinkStatus is the status of a link's target (System.Link.Status).
+	LinkStatus = PropertyKey{FormatID: link, PID: 3}
+
+	// LinkComment is the comment of a link (System.Link.Comment).
+	LinkComment = PropertyKey{FormatID: link, PID: 5}
+
+	// LinkTargetSFGAOFlags is the shell attributes of a link's target
+	// (System.Link.TargetSFGAOFlags).
+	LinkTargetSFGAOFlags = PropertyKey{FormatID: link, PID: 8}
+
+	// LinkArguments is the command line arguments of a link
+	// (System.Link.Arguments).
+	//
+	//	{436F2667-14E2-4FEB-B30A-146C53B5B674} 100
+	LinkArguments = PropertyKey{FormatID: uuid.UUID{0x43, 0x6F, 0x26, 0x67, 0x14, 0xE2, 0x4F, 0xEB, 0xB3, 0x0A, 0x14, 0x6C, 0x53, 0xB5, 0xB6, 0x74}, PID: 100}
+
+	// LinkTargetExtension is the file extension of a link's target
+	// (System.Link.TargetExtension).
+	//
+	//	{7A7D76F4-B630-4BD7-95FF-37CC51A975C9} 2
+	LinkTargetExtension = PropertyKey{FormatID: uuid.UUID{0x7A, 0x7D, 0x76, 0xF4, 0xB6, 0x30, 0x4B, 0xD7, 0x95, 0xFF, 0x37, 0xCC, 0x51, 0xA9, 0x75, 0xC9}, PID: 2}
+
+	// LinkTargetURL is the URL targeted by an internet shortcut
+	// (System.Link.TargetUrl).
+	LinkTargetURL = PropertyKey{FormatID: internetShortcut, PID: 2}
+
+	// LinkDateVisited is the time an internet shortcut's target was last
+	// visited (System.Link.DateVisited).
+	LinkDateVisited = PropertyKey{FormatID: internetShortcut, PID: 23}
+)
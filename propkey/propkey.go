@@ -0,0 +1,101 @@
+// Package propkey provides property keys for the Windows property system.
+//
+// A property key identifies a property by its format identifier and
+// property identifier. Well-known keys also have a canonical name, such
+// as System.Title, which can be used to look them up.
+package propkey
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// PropertyKey identifies a property in the Windows property system. It
+// corresponds to a PROPERTYKEY structure.
+type PropertyKey struct {
+	FormatID uuid.UUID
+	PID      uint32
+}
+
+// Name returns the canonical name of the key, such as System.Title. It
+// returns false if the key is not in the catalog.
+func (key PropertyKey) Name() (string, bool) {
+	name, ok := names[key]
+	return name, ok
+}
+
+// String returns the canonical name of the key if it has one. Otherwise
+// it returns the format identifier and property identifier in the form
+// used by the Windows property system:
+//
+//	{9F4C2855-9F79-4B39-A8D0-E1D42DE1D5F3} 5
+func (key PropertyKey) String() string {
+	if name, ok := names[key]; ok {
+		return name
+	}
+	return key.GUIDString()
+}
+
+// GUIDString returns the format identifier and property identifier of the
+// key, regardless of whether it has a canonical name.
+func (key PropertyKey) GUIDString() string {
+	return fmt.Sprintf("{%s} %d", strings.ToUpper(key.FormatID.String()), key.PID)
+}
+
+// MarshalText returns the text representation of the key, as returned by
+// String.
+func (key PropertyKey) MarshalText() ([]byte, error) {
+	return []byte(key.String()), nil
+}
+
+// UnmarshalText parses a property key from text in any form accepted by
+// Parse.
+func (key *PropertyKey) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*key = parsed
+	return nil
+}
+
+// Lookup returns the property key with the given canonical name. Names
+// are matched without regard to case. It returns false if the name is
+// not in the catalog.
+func Lookup(name string) (PropertyKey, bool) {
+	key, ok := keys[strings.ToLower(name)]
+	return key, ok
+}
+
+// Parse returns the property key described by s, which is either a
+// canonical name or a format identifier followed by a property
+// identifier:
+//
+//	System.AppUserModel.ID
+//	{9F4C2855-9F79-4B39-A8D0-E1D42DE1D5F3} 5
+func Parse(s string) (PropertyKey, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "{") {
+		if key, ok := Lookup(s); ok {
+			return key, nil
+		}
+		return PropertyKey{}, fmt.Errorf("unknown property name \"%s\"", s)
+	}
+
+	end := strings.IndexByte(s, '}')
+	if end < 0 {
+		return PropertyKey{}, fmt.Errorf("invalid property key \"%s\"", s)
+	}
+	fmtid, err := uuid.Parse(s[1:end])
+	if err != nil {
+		return PropertyKey{}, fmt.Errorf("invalid format identifier in \"%s\": %v", s, err)
+	}
+	pid, err := strconv.ParseUint(strings.TrimSpace(s[end+1:]), 10, 32)
+	if err != nil {
+		return PropertyKey{}, fmt.Errorf("invalid property identifier in \"%s\": %v", s, err)
+	}
+	return PropertyKey{FormatID: fmtid, PID: uint32(pid)}, nil
+}
@@ -0,0 +1,90 @@
+package propkey_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gentlemanautomaton/winshell/propkey"
+	"github.com/google/uuid"
+)
+
+func TestCatalog(t *testing.T) {
+	seen := make(map[propkey.PropertyKey]string)
+	for _, name := range propkey.Names() {
+		key, ok := propkey.Lookup(name)
+		if !ok {
+			t.Errorf("%s: not found by name", name)
+			continue
+		}
+		if other, dup := seen[key]; dup {
+			t.Errorf("%s: shares key %s with %s", name, key.GUIDString(), other)
+		}
+		seen[key] = name
+		if got, ok := key.Name(); !ok || got != name {
+			t.Errorf("%s: reverse lookup returned %q (%t)", name, got, ok)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		Input string
+		Key   propkey.PropertyKey
+	}{
+		{"System.Title", propkey.Title},
+		{"system.appusermodel.id", propkey.AppUserModelID},
+		{"System.Link.TargetParsingPath", propkey.LinkTargetParsingPath},
+		{"{9F4C2855-9F79-4B39-A8D0-E1D42DE1D5F3} 5", propkey.AppUserModelID},
+		{"{9f4c2855-9f79-4b39-a8d0-e1d42de1d5f3}26", propkey.AppUserModelToastActivatorCLSID},
+		{"{00000000-0000-0000-0000-000000000001} 7", propkey.PropertyKey{FormatID: uuid.UUID{15: 1}, PID: 7}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Input, func(t *testing.T) {
+			key, err := propkey.Parse(test.Input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if key != test.Key {
+				t.Errorf("got %s, want %s", key.GUIDString(), test.Key.GUIDString())
+			}
+		})
+	}
+
+	for _, input := range []string{"System.NoSuchProperty", "{9F4C2855} 5", "{9F4C2855-9F79-4B39-A8D0-E1D42DE1D5F3}", "{9F4C2855-9F79-4B39-A8D0-E1D42DE1D5F3 5"} {
+		if _, err := propkey.Parse(input); err == nil {
+			t.Errorf("%q: parsed without error", input)
+		}
+	}
+}
+
+func TestPropertyKeyText(t *testing.T) {
+	unknown := propkey.PropertyKey{FormatID: propkey.AppUserModelID.FormatID, PID: 99}
+	for _, key := range []propkey.PropertyKey{propkey.AppUserModelID, unknown} {
+		text, err := key.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var parsed propkey.PropertyKey
+		if err := parsed.UnmarshalText(text); err != nil {
+			t.Fatal(err)
+		}
+		if parsed != key {
+			t.Errorf("%s: round trip produced %s", text, parsed.GUIDString())
+		}
+	}
+	if s := unknown.String(); !strings.HasPrefix(s, "{9F4C2855-") {
+		t.Errorf("unknown key: got %s", s)
+	}
+}
+
+func ExamplePropertyKey_String() {
+	fmt.Println(propkey.AppUserModelID)
+	fmt.Println(propkey.AppUserModelID.GUIDString())
+	fmt.Println(propkey.PropertyKey{FormatID: propkey.AppUserModelID.FormatID, PID: 99})
+	// Output:
+	// System.AppUserModel.ID
+	// {9F4C2855-9F79-4B39-A8D0-E1D42DE1D5F3} 5
+	// {9F4C2855-9F79-4B39-A8D0-E1D42DE1D5F3} 99
+}
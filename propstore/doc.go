@@ -0,0 +1,4 @@
+// Package propstore encodes and decodes serialized property storage, as
+// defined by MS-PROPSTORE. Serialized property storage is found in shell
+// links, shell items and other shell data structures.
+package propstore
@@ -0,0 +1,312 @@
+package propstore
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/gentlemanautomaton/winshell/internal/guid"
+	"github.com/gentlemanautomaton/winshell/internal/utf16le"
+	"github.com/gentlemanautomaton/winshell/propkey"
+	"github.com/gentlemanautomaton/winshell/propvariant"
+	"github.com/google/uuid"
+	"golang.org/x/text/encoding"
+)
+
+// storageVersion is the version of each serialized property storage,
+// which spells "1SPS" when stored in little-endian byte order.
+const storageVersion = 0x53505331
+
+// storageHeaderSize is the number of bytes in the size, version and
+// format identifier that begin each serialized property storage.
+const storageHeaderSize = 4 + 4 + guid.Size
+
+// valueHeaderSize is the number of bytes in the size, identifier and
+// reserved byte that begin each serialized property value.
+const valueHeaderSize = 4 + 4 + 1
+
+// NamedFormat is the format identifier of property storage in which
+// properties are identified by name instead of by numeric identifier.
+//
+//	{D5CDD505-2E9C-101B-9397-08002B2CF9AE}
+var NamedFormat = uuid.UUID{0xD5, 0xCD, 0xD5, 0x05, 0x2E, 0x9C, 0x10, 0x1B, 0x93, 0x97, 0x08, 0x00, 0x2B, 0x2C, 0xF9, 0xAE}
+
+// Store is a property store. It holds a sequence of property sets.
+type Store []Set
+
+// Set is a set of properties that share a format identifier. It
+// corresponds to a serialized property storage structure.
+type Set struct {
+	FormatID   uuid.UUID
+	Properties []Property
+}
+
+// Property is a property value within a property set.
+//
+// Properties within a set that has the NamedFormat format identifier are
+// identified by Name. All other properties are identified by ID.
+type Property struct {
+	ID    uint32
+	Name  string
+	Value propvariant.Value
+}
+
+// Get returns the value of the property identified by key. It returns
+// false if the store does not hold the property.
+func (store Store) Get(key propkey.PropertyKey) (propvariant.Value, bool) {
+	for _, set := range store {
+		if set.FormatID != key.FormatID {
+			continue
+		}
+		for _, prop := range set.Properties {
+			if prop.ID == key.PID {
+				return prop.Value, true
+			}
+		}
+	}
+	return propvariant.Value{}, false
+}
+
+// Keys returns the keys of the properties in the store, in the order
+// they are stored. Properties that are identified by name are omitted.
+func (store Store) Keys() []propkey.PropertyKey {
+	var keys []propkey.PropertyKey
+	for _, set := range store {
+		if set.FormatID == NamedFormat {
+			continue
+		}
+		for _, prop := range set.Properties {
+			keys = append(keys, propkey.PropertyKey{FormatID: set.FormatID, PID: prop.ID})
+		}
+	}
+	return keys
+}
+
+// Set stores the value of the property identified by key. An existing
+// property is updated in place. Otherwise the property is added to the
+// first set with a matching format identifier, or to a new set at the end
+// of the store.
+func (store *Store) Set(key propkey.PropertyKey, value propvariant.Value) {
+	for s := range *store {
+		set := &(*store)[s]
+		if set.FormatID != key.FormatID {
+			continue
+		}
+		for p := range set.Properties {
+			if set.Properties[p].ID == key.PID {
+				set.Properties[p].Value = value
+				return
+			}
+		}
+	}
+	for s := range *store {
+		set := &(*store)[s]
+		if set.FormatID == key.FormatID {
+			set.Properties = append(set.Properties, Property{ID: key.PID, Value: value})
+			return
+		}
+	}
+	*store = append(*store, Set{FormatID: key.FormatID, Properties: []Property{{ID: key.PID, Value: value}}})
+}
+
+// Delete removes the property identified by key. Sets that are left empty
+// are removed.
+func (store *Store) Delete(key propkey.PropertyKey) {
+	sets := (*store)[:0]
+	for _, set := range *store {
+		if set.FormatID == key.FormatID {
+			props := set.Properties[:0]
+			for _, prop := range set.Properties {
+				if prop.ID != key.PID {
+					props = append(props, prop)
+				}
+			}
+			set.Properties = props
+			if len(props) == 0 {
+				continue
+			}
+		}
+		sets = append(sets, set)
+	}
+	*store = sets
+}
+
+// UnmarshalBinary parses a property store from data. The data must hold
+// a sequence of serialized property storage structures followed by a
+// terminal. A missing terminal at the end of data is tolerated.
+func (store *Store) UnmarshalBinary(data []byte) error {
+	return store.UnmarshalANSI(data, nil)
+}
+
+// UnmarshalANSI parses a property store from data in the same manner as
+// UnmarshalBinary, but decodes VT_LPSTR values with enc. A nil encoding
+// selects Windows-1252.
+func (store *Store) UnmarshalANSI(data []byte, enc encoding.Encoding) error {
+	*store = Store{}
+	for offset := 0; ; {
+		remaining := len(data) - offset
+		if remaining == 0 {
+			return nil
+		}
+		if remaining < 4 {
+			return fmt.Errorf("the property store is truncated at offset %d", offset)
+		}
+		size := int(binary.LittleEndian.Uint32(data[offset : offset+4]))
+		if size == 0 {
+			if remaining > 4 {
+				return fmt.Errorf("the property store has %d bytes of trailing data after its terminal", remaining-4)
+			}
+			return nil
+		}
+		if size < storageHeaderSize || size > remaining {
+			return fmt.Errorf("the property storage at offset %d declares an invalid size of %d bytes", offset, size)
+		}
+		var set Set
+		if err := set.UnmarshalANSI(data[offset:offset+size], enc); err != nil {
+			return fmt.Errorf("failed to parse property storage at offset %d: %v", offset, err)
+		}
+		*store = append(*store, set)
+		offset += size
+	}
+}
+
+// MarshalBinary returns the binary representation of the property store,
+// including its terminal.
+func (store Store) MarshalBinary() ([]byte, error) {
+	return store.MarshalANSI(nil)
+}
+
+// MarshalANSI returns the binary representation of the property store,
+// encoding VT_LPSTR values with enc. A nil encoding selects Windows-1252.
+func (store Store) MarshalANSI(enc encoding.Encoding) ([]byte, error) {
+	var data []byte
+	for _, set := range store {
+		encoded, err := set.MarshalANSI(enc)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, encoded...)
+	}
+	return binary.LittleEndian.AppendUint32(data, 0), nil
+}
+
+// UnmarshalBinary parses a serialized property storage structure from
+// data, which must hold exactly one structure.
+func (set *Set) UnmarshalBinary(data []byte) error {
+	return set.UnmarshalANSI(data, nil)
+}
+
+// UnmarshalANSI parses a serialized property storage structure from data
+// in the same manner as UnmarshalBinary, but decodes VT_LPSTR values with
+// enc. A nil encoding selects Windows-1252.
+func (set *Set) UnmarshalANSI(data []byte, enc encoding.Encoding) error {
+	if len(data) < storageHeaderSize {
+		return fmt.Errorf("the property storage requires at least %d bytes, but only %d bytes are present", storageHeaderSize, len(data))
+	}
+	if size := int(binary.LittleEndian.Uint32(data[0:4])); size != len(data) {
+		return fmt.Errorf("the property storage declares a size of %d bytes, but %d bytes are present", size, len(data))
+	}
+	if version := binary.LittleEndian.Uint32(data[4:8]); version != storageVersion {
+		return fmt.Errorf("the property storage has an unrecognized version of %#x", version)
+	}
+
+	*set = Set{FormatID: guid.Decode(data[8 : 8+guid.Size])}
+	named := set.FormatID == NamedFormat
+
+	for offset := storageHeaderSize; ; {
+		remaining := len(data) - offset
+		if remaining < 4 {
+			// Tolerate a missing terminal at the end of the storage
+			if remaining == 0 {
+				return nil
+			}
+			return fmt.Errorf("the property storage is truncated at offset %d", offset)
+		}
+		size := int(binary.LittleEndian.Uint32(data[offset : offset+4]))
+		if size == 0 {
+			return nil
+		}
+		if size < valueHeaderSize || size > remaining {
+			return fmt.Errorf("the property value at offset %d declares an invalid size of %d bytes", offset, size)
+		}
+
+		var prop Property
+		if err := prop.unmarshal(data[offset:offset+size], named, enc); err != nil {
+			return fmt.Errorf("failed to parse property value at offset %d: %v", offset, err)
+		}
+		set.Properties = append(set.Properties, prop)
+		offset += size
+	}
+}
+
+// MarshalBinary returns the binary representation of the property set as
+// a serialized property storage structure.
+func (set Set) MarshalBinary() ([]byte, error) {
+	return set.MarshalANSI(nil)
+}
+
+// MarshalANSI returns the binary representation of the property set,
+// encoding VT_LPSTR values with enc. A nil encoding selects Windows-1252.
+func (set Set) MarshalANSI(enc encoding.Encoding) ([]byte, error) {
+	data := make([]byte, storageHeaderSize)
+	binary.LittleEndian.PutUint32(data[4:8], storageVersion)
+	guid.Put(data[8:8+guid.Size], set.FormatID)
+
+	named := set.FormatID == NamedFormat
+	for _, prop := range set.Properties {
+		encoded, err := prop.marshal(named, enc)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, encoded...)
+	}
+	data = binary.LittleEndian.AppendUint32(data, 0)
+
+	binary.LittleEndian.PutUint32(data[0:4], uint32(len(data)))
+	return data, nil
+}
+
+// unmarshal parses a serialized property value from data, decoding
+// VT_LPSTR values with enc. If named is true the property is identified
+// by name.
+func (prop *Property) unmarshal(data []byte, named bool, enc encoding.Encoding) error {
+	*prop = Property{}
+	value := data[valueHeaderSize:]
+	if named {
+		nameSize := int(binary.LittleEndian.Uint32(data[4:8]))
+		if nameSize > len(value) {
+			return fmt.Errorf("the property name declares a size of %d bytes, but only %d bytes remain", nameSize, len(value))
+		}
+		name, _, ok := utf16le.DecodeZ(value[:nameSize])
+		if !ok {
+			return fmt.Errorf("the property name is not null-terminated")
+		}
+		prop.Name = name
+		value = value[nameSize:]
+	} else {
+		prop.ID = binary.LittleEndian.Uint32(data[4:8])
+	}
+	return prop.Value.UnmarshalANSI(value, enc)
+}
+
+// marshal returns the binary representation of the property as a
+// serialized property value, encoding VT_LPSTR values with enc. If named
+// is true the property is identified by name.
+func (prop Property) marshal(named bool, enc encoding.Encoding) ([]byte, error) {
+	value, err := prop.Value.MarshalANSI(enc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal property value: %v", err)
+	}
+
+	data := make([]byte, valueHeaderSize)
+	if named {
+		name := utf16le.EncodeZ(prop.Name)
+		binary.LittleEndian.PutUint32(data[4:8], uint32(len(name)))
+		data = append(data, name...)
+	} else {
+		binary.LittleEndian.PutUint32(data[4:8], prop.ID)
+	}
+	data = append(data, value...)
+
+	binary.LittleEndian.PutUint32(data[0:4], uint32(len(data)))
+	return data, nil
+}
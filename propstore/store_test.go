@@ -0,0 +1,119 @@
+package propstore_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/gentlemanautomaton/winshell/propkey"
+	"github.com/gentlemanautomaton/winshell/propstore"
+	"github.com/gentlemanautomaton/winshell/propvariant"
+	"github.com/google/uuid"
+)
+
+// appUserModelStore returns a hand-assembled property store that holds a
+// System.AppUserModel.ID of "App.ID".
+func appUserModelStore() []byte {
+	const s = "" +
+		"3d000000" + "31535053" + "55284c9f799f394ba8d0e1d42de1d5f3" + // storage header
+		"21000000" + "05000000" + "00" + // value header
+		"1f000000" + "07000000" + "4100700070002e00490044000000" + "0000" + // VT_LPWSTR "App.ID"
+		"00000000" + // value terminal
+		"00000000" // storage terminal
+	data, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+func TestStoreUnmarshalBinary(t *testing.T) {
+	var store propstore.Store
+	if err := store.UnmarshalBinary(appUserModelStore()); err != nil {
+		t.Fatal(err)
+	}
+
+	value, ok := store.Get(propkey.AppUserModelID)
+	if !ok {
+		t.Fatal("the property was not found")
+	}
+	if id, ok := value.AsString(); !ok || id != "App.ID" {
+		t.Errorf("got %s %q, want VT_LPWSTR \"App.ID\"", value.Type(), id)
+	}
+
+	data, err := store.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, appUserModelStore()) {
+		t.Errorf("marshaled store differs:\n got %x\nwant %x", data, appUserModelStore())
+	}
+}
+
+func TestStoreSet(t *testing.T) {
+	activator := uuid.MustParse("5A8E3C1F-1E0B-4C35-9E1B-7A0F6C2D9E40")
+
+	var store propstore.Store
+	store.Set(propkey.AppUserModelID, propvariant.NewString("Original"))
+	store.Set(propkey.AppUserModelToastActivatorCLSID, propvariant.NewGUID(activator))
+	store.Set(propkey.AppUserModelID, propvariant.NewString("Updated"))
+	store = append(store, propstore.Set{
+		FormatID:   propstore.NamedFormat,
+		Properties: []propstore.Property{{Name: "Custom", Value: propvariant.NewString("named")}},
+	})
+
+	data, err := store.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded propstore.Store
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded) != 2 || len(decoded[0].Properties) != 2 {
+		t.Fatalf("unexpected store layout: %+v", decoded)
+	}
+	if value, _ := decoded.Get(propkey.AppUserModelID); value.String() != "Updated" {
+		t.Errorf("ID: got %s", value)
+	}
+	if value, _ := decoded.Get(propkey.AppUserModelToastActivatorCLSID); !strings.EqualFold(value.String(), "{"+activator.String()+"}") {
+		t.Errorf("activator: got %s", value)
+	}
+	if name := decoded[1].Properties[0].Name; name != "Custom" {
+		t.Errorf("name: got %q", name)
+	}
+	if keys := decoded.Keys(); len(keys) != 2 || keys[0] != propkey.AppUserModelID || keys[1] != propkey.AppUserModelToastActivatorCLSID {
+		t.Errorf("keys: got %v", keys)
+	}
+
+	decoded.Delete(propkey.AppUserModelID)
+	decoded.Delete(propkey.AppUserModelToastActivatorCLSID)
+	if len(decoded) != 1 || decoded[0].FormatID != propstore.NamedFormat {
+		t.Errorf("deleting every property in a set did not remove it: %+v", decoded)
+	}
+}
+
+func TestStoreUnsupportedType(t *testing.T) {
+	data := appUserModelStore()
+	// Change the value type to VT_STREAM, which is not supported
+	data[33] = 0x42
+
+	var store propstore.Store
+	if err := store.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	value, _ := store.Get(propkey.AppUserModelID)
+	if !value.IsRaw() {
+		t.Errorf("a value of an unsupported type was decoded as %s", value.Type())
+	}
+
+	encoded, err := store.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(encoded, data) {
+		t.Errorf("a value of an unsupported type was not preserved")
+	}
+}
@@ -0,0 +1,370 @@
+package propvariant
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gentlemanautomaton/winshell/internal/filetime"
+	"github.com/gentlemanautomaton/winshell/internal/guid"
+	"github.com/gentlemanautomaton/winshell/internal/utf16le"
+	"github.com/google/uuid"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+)
+
+// Boolean values, as stored by VT_BOOL.
+const (
+	variantTrue  = 0xFFFF
+	variantFalse = 0x0000
+)
+
+// ansiEncoding returns enc, or Windows-1252 if enc is nil.
+func ansiEncoding(enc encoding.Encoding) encoding.Encoding {
+	if enc == nil {
+		return charmap.Windows1252
+	}
+	return enc
+}
+
+// decode decodes the payload of a value of type vt from data, decoding
+// VT_LPSTR strings with enc. It returns the decoded value and the number
+// of bytes consumed, including padding.
+func decode(vt VarType, data []byte, enc encoding.Encoding) (value any, n int, err error) {
+	if vt == Empty {
+		return nil, 0, nil
+	}
+	if vt.IsVector() {
+		return decodeVector(vt, data, enc)
+	}
+	value, n, err = decodeElem(vt, data, enc)
+	return value, pad4(n), err
+}
+
+// encode appends the payload of a value of type vt to data, including
+// padding. VT_LPSTR strings are encoded with enc.
+func encode(data []byte, vt VarType, value any, enc encoding.Encoding) ([]byte, error) {
+	if vt == Empty {
+		return data, nil
+	}
+	start := len(data)
+	var err error
+	if vt.IsVector() {
+		data, err = encodeVector(data, vt, value, enc)
+	} else {
+		data, err = encodeElem(data, vt, value, enc)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return appendPadding(data, len(data)-start), nil
+}
+
+// decodeElem decodes a single scalar value of type vt from data. It
+// returns the number of bytes consumed, which includes padding only for
+// types that are padded individually.
+func decodeElem(vt VarType, data []byte, enc encoding.Encoding) (value any, n int, err error) {
+	fixed := func(size int) error {
+		if len(data) < size {
+			return errTruncated(vt)
+		}
+		return nil
+	}
+
+	switch vt {
+	case I4:
+		if err := fixed(4); err != nil {
+			return nil, 0, err
+		}
+		return int32(binary.LittleEndian.Uint32(data)), 4, nil
+	case Bool:
+		if err := fixed(2); err != nil {
+			return nil, 0, err
+		}
+		return binary.LittleEndian.Uint16(data) != variantFalse, 2, nil
+	case UI4:
+		if err := fixed(4); err != nil {
+			return nil, 0, err
+		}
+		return binary.LittleEndian.Uint32(data), 4, nil
+	case I8:
+		if err := fixed(8); err != nil {
+			return nil, 0, err
+		}
+		return int64(binary.LittleEndian.Uint64(data)), 8, nil
+	case UI8:
+		if err := fixed(8); err != nil {
+			return nil, 0, err
+		}
+		return binary.LittleEndian.Uint64(data), 8, nil
+	case FileTime:
+		if err := fixed(8); err != nil {
+			return nil, 0, err
+		}
+		return filetime.ToTime(binary.LittleEndian.Uint64(data)), 8, nil
+	case CLSID:
+		if err := fixed(guid.Size); err != nil {
+			return nil, 0, err
+		}
+		return guid.Decode(data[:guid.Size]), guid.Size, nil
+	case LPSTR:
+		return decodeCodePageString(data, enc)
+	case LPWSTR:
+		return decodeUnicodeString(data)
+	case Blob:
+		b, n, err := decodeSized(vt, data, 1)
+		return b, n, err
+	default:
+		return nil, 0, fmt.Errorf("the %s type is not supported", vt)
+	}
+}
+
+// encodeElem appends a single scalar value of type vt to data. Padding is
+// included only for types that are padded individually.
+func encodeElem(data []byte, vt VarType, value any, enc encoding.Encoding) ([]byte, error) {
+	switch vt {
+	case I4:
+		if v, ok := value.(int32); ok {
+			return binary.LittleEndian.AppendUint32(data, uint32(v)), nil
+		}
+	case Bool:
+		if v, ok := value.(bool); ok {
+			if v {
+				return binary.LittleEndian.AppendUint16(data, variantTrue), nil
+			}
+			return binary.LittleEndian.AppendUint16(data, variantFalse), nil
+		}
+	case UI4:
+		if v, ok := value.(uint32); ok {
+			return binary.LittleEndian.AppendUint32(data, v), nil
+		}
+	case I8:
+		if v, ok := value.(int64); ok {
+			return binary.LittleEndian.AppendUint64(data, uint64(v)), nil
+		}
+	case UI8:
+		if v, ok := value.(uint64); ok {
+			return binary.LittleEndian.AppendUint64(data, v), nil
+		}
+	case FileTime:
+		if v, ok := value.(time.Time); ok {
+			return binary.LittleEndian.AppendUint64(data, filetime.FromTime(v)), nil
+		}
+	case CLSID:
+		if v, ok := value.(uuid.UUID); ok {
+			return append(data, guid.Bytes(v)...), nil
+		}
+	case LPSTR:
+		if v, ok := value.(string); ok {
+			return appendCodePageString(data, v, enc), nil
+		}
+	case LPWSTR:
+		if v, ok := value.(string); ok {
+			return appendUnicodeString(data, v), nil
+		}
+	case Blob:
+		if v, ok := value.([]byte); ok {
+			data = binary.LittleEndian.AppendUint32(data, uint32(len(v)))
+			data = append(data, v...)
+			return appendPadding(data, len(v)), nil
+		}
+	default:
+		return nil, fmt.Errorf("the %s type is not supported", vt)
+	}
+	return nil, errMismatch(vt, value)
+}
+
+// decodeVector decodes a vector of values of type vt from data. It
+// returns the number of bytes consumed, including padding.
+func decodeVector(vt VarType, data []byte, enc encoding.Encoding) (value any, n int, err error) {
+	switch vt.Elem() {
+	case I4:
+		return decodeElems[int32](vt, data, enc)
+	case Bool:
+		return decodeElems[bool](vt, data, enc)
+	case UI4:
+		return decodeElems[uint32](vt, data, enc)
+	case I8:
+		return decodeElems[int64](vt, data, enc)
+	case UI8:
+		return decodeElems[uint64](vt, data, enc)
+	case FileTime:
+		return decodeElems[time.Time](vt, data, enc)
+	case CLSID:
+		return decodeElems[uuid.UUID](vt, data, enc)
+	case LPSTR, LPWSTR:
+		return decodeElems[string](vt, data, enc)
+	default:
+		return nil, 0, fmt.Errorf("the %s type is not supported", vt)
+	}
+}
+
+// decodeElems decodes a vector of elements of type T from data.
+func decodeElems[T any](vt VarType, data []byte, enc encoding.Encoding) (value any, n int, err error) {
+	if len(data) < 4 {
+		return nil, 0, errTruncated(vt)
+	}
+	count := int(binary.LittleEndian.Uint32(data[0:4]))
+	if count > len(data) {
+		return nil, 0, errTruncated(vt)
+	}
+	n = 4
+	elems := make([]T, 0, count)
+	for i := 0; i < count; i++ {
+		elem, size, err := decodeElem(vt.Elem(), data[n:], enc)
+		if err != nil {
+			return nil, 0, err
+		}
+		elems = append(elems, elem.(T))
+		n += size
+	}
+	return elems, pad4(n), nil
+}
+
+// encodeVector appends a vector of values of type vt to data, excluding
+// the padding that follows the vector.
+func encodeVector(data []byte, vt VarType, value any, enc encoding.Encoding) ([]byte, error) {
+	switch vt.Elem() {
+	case I4:
+		return encodeElems[int32](data, vt, value, enc)
+	case Bool:
+		return encodeElems[bool](data, vt, value, enc)
+	case UI4:
+		return encodeElems[uint32](data, vt, value, enc)
+	case I8:
+		return encodeElems[int64](data, vt, value, enc)
+	case UI8:
+		return encodeElems[uint64](data, vt, value, enc)
+	case FileTime:
+		return encodeElems[time.Time](data, vt, value, enc)
+	case CLSID:
+		return encodeElems[uuid.UUID](data, vt, value, enc)
+	case LPSTR, LPWSTR:
+		return encodeElems[string](data, vt, value, enc)
+	default:
+		return nil, fmt.Errorf("the %s type is not supported", vt)
+	}
+}
+
+// encodeElems appends a vector of elements of type T to data.
+func encodeElems[T any](data []byte, vt VarType, value any, enc encoding.Encoding) ([]byte, error) {
+	elems, ok := value.([]T)
+	if !ok {
+		return nil, errMismatch(vt, value)
+	}
+	data = binary.LittleEndian.AppendUint32(data, uint32(len(elems)))
+	for _, elem := range elems {
+		var err error
+		if data, err = encodeElem(data, vt.Elem(), elem, enc); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// decodeSized decodes a length-prefixed sequence of units of the given
+// size that is padded to a multiple of 4 bytes. It returns a copy of the
+// sequence and the number of bytes consumed, including padding.
+func decodeSized(vt VarType, data []byte, unit int) (b []byte, n int, err error) {
+	if len(data) < 4 {
+		return nil, 0, errTruncated(vt)
+	}
+	length := int(binary.LittleEndian.Uint32(data[0:4]))
+	if length > len(data)/unit {
+		return nil, 0, errTruncated(vt)
+	}
+	size := length * unit
+	n = 4 + pad4(size)
+	if n > len(data) {
+		return nil, 0, errTruncated(vt)
+	}
+	return bytes.Clone(data[4 : 4+size]), n, nil
+}
+
+// decodeUnicodeString decodes a length-prefixed, null-terminated UTF-16
+// string that is padded to a multiple of 4 bytes.
+func decodeUnicodeString(data []byte) (s string, n int, err error) {
+	chars, n, err := decodeSized(LPWSTR, data, 2)
+	if err != nil {
+		return "", 0, err
+	}
+	if len(chars) > 0 {
+		if chars[len(chars)-2] != 0 || chars[len(chars)-1] != 0 {
+			return "", 0, fmt.Errorf("the %s value is not null-terminated", LPWSTR)
+		}
+		chars = chars[:len(chars)-2]
+	}
+	return utf16le.Decode(chars), n, nil
+}
+
+// appendUnicodeString appends s to data as a length-prefixed,
+// null-terminated UTF-16 string that is padded to a multiple of 4 bytes.
+func appendUnicodeString(data []byte, s string) []byte {
+	encoded := utf16le.EncodeZ(s)
+	data = binary.LittleEndian.AppendUint32(data, uint32(len(encoded)/2))
+	data = append(data, encoded...)
+	return appendPadding(data, len(encoded))
+}
+
+// decodeCodePageString decodes a length-prefixed, null-terminated string
+// in the code page of enc that is padded to a multiple of 4 bytes.
+func decodeCodePageString(data []byte, enc encoding.Encoding) (s string, n int, err error) {
+	chars, n, err := decodeSized(LPSTR, data, 1)
+	if err != nil {
+		return "", 0, err
+	}
+	if len(chars) > 0 {
+		if chars[len(chars)-1] != 0 {
+			return "", 0, fmt.Errorf("the %s value is not null-terminated", LPSTR)
+		}
+		chars = chars[:len(chars)-1]
+	}
+	decoded, err := ansiEncoding(enc).NewDecoder().Bytes(chars)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to decode %s value: %v", LPSTR, err)
+	}
+	return string(decoded), n, nil
+}
+
+// appendCodePageString appends s to data as a length-prefixed,
+// null-terminated string in the code page of enc that is padded to a
+// multiple of 4 bytes. Characters that cannot be represented are
+// replaced with a question mark.
+func appendCodePageString(data []byte, s string, enc encoding.Encoding) []byte {
+	encoder := ansiEncoding(enc).NewEncoder()
+	encoded := make([]byte, 0, len(s)+1)
+	var buf [utf8.UTFMax]byte
+	for _, r := range s {
+		b, err := encoder.Bytes(buf[:utf8.EncodeRune(buf[:], r)])
+		if err != nil {
+			b = []byte{'?'}
+		}
+		encoded = append(encoded, b...)
+	}
+	encoded = append(encoded, 0)
+
+	data = binary.LittleEndian.AppendUint32(data, uint32(len(encoded)))
+	data = append(data, encoded...)
+	return appendPadding(data, len(encoded))
+}
+
+// pad4 returns n rounded up to a multiple of 4.
+func pad4(n int) int {
+	return (n + 3) &^ 3
+}
+
+// appendPadding appends the zero bytes needed to pad a field of n bytes to
+// a multiple of 4 bytes.
+func appendPadding(data []byte, n int) []byte {
+	return append(data, make([]byte, pad4(n)-n)...)
+}
+
+func errTruncated(vt VarType) error {
+	return fmt.Errorf("the %s value is truncated", vt)
+}
+
+func errMismatch(vt VarType, value any) error {
+	return fmt.Errorf("a %s value cannot hold a value of type %T", vt, value)
+}
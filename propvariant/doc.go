@@ -0,0 +1,4 @@
+// Package propvariant provides typed property values as they are
+// serialized in property stores. It implements the TypedPropertyValue
+// structure defined by MS-OLEPS.
+package propvariant
@@ -0,0 +1,346 @@
+package propvariant
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/text/encoding"
+)
+
+// headerSize is the number of bytes in the type and padding that begin
+// each typed property value.
+const headerSize = 4
+
+// Value is a typed property value. The zero value is an empty value of
+// type VT_EMPTY.
+//
+// Each supported type is held as a Go value:
+//
+//	VT_I4        int32
+//	VT_BOOL      bool
+//	VT_UI4       uint32
+//	VT_I8        int64
+//	VT_UI8       uint64
+//	VT_LPSTR     string
+//	VT_LPWSTR    string
+//	VT_FILETIME  time.Time
+//	VT_BLOB      []byte
+//	VT_CLSID     uuid.UUID
+//
+// Vectors of each of these types other than VT_BLOB are held as slices.
+// VT_LPSTR values are encoded as Windows-1252 by UnmarshalBinary and
+// MarshalBinary. UnmarshalANSI and MarshalANSI accept the encoding of
+// another code page.
+//
+// Values of types that this package does not support are held in their
+// binary form, including their header, so that they can be marshaled
+// without loss.
+type Value struct {
+	vt  VarType
+	v   any
+	raw []byte
+}
+
+// New returns a value of type vt that holds v. It returns an error if the
+// Go type of v does not match vt.
+func New(vt VarType, v any) (Value, error) {
+	value := Value{vt: vt, v: v}
+	if vt == Empty {
+		value.v = nil
+	}
+	if _, err := encode(nil, vt, value.v, nil); err != nil {
+		return Value{}, err
+	}
+	return value, nil
+}
+
+// From returns a value that holds v, choosing its type from the Go type
+// of v. Strings are stored as VT_LPWSTR. A nil value returns an empty
+// value.
+func From(v any) (Value, error) {
+	var vt VarType
+	switch v.(type) {
+	case nil:
+		return Value{}, nil
+	case int32:
+		vt = I4
+	case bool:
+		vt = Bool
+	case uint32:
+		vt = UI4
+	case int64:
+		vt = I8
+	case uint64:
+		vt = UI8
+	case string:
+		vt = LPWSTR
+	case time.Time:
+		vt = FileTime
+	case []byte:
+		vt = Blob
+	case uuid.UUID:
+		vt = CLSID
+	case []int32:
+		vt = Vector | I4
+	case []bool:
+		vt = Vector | Bool
+	case []uint32:
+		vt = Vector | UI4
+	case []int64:
+		vt = Vector | I8
+	case []uint64:
+		vt = Vector | UI8
+	case []string:
+		vt = Vector | LPWSTR
+	case []time.Time:
+		vt = Vector | FileTime
+	case []uuid.UUID:
+		vt = Vector | CLSID
+	default:
+		return Value{}, fmt.Errorf("values of type %T cannot be stored as property values", v)
+	}
+	return Value{vt: vt, v: v}, nil
+}
+
+// NewString returns a VT_LPWSTR value holding s.
+func NewString(s string) Value {
+	return Value{vt: LPWSTR, v: s}
+}
+
+// NewANSIString returns a VT_LPSTR value holding s.
+func NewANSIString(s string) Value {
+	return Value{vt: LPSTR, v: s}
+}
+
+// NewBool returns a VT_BOOL value holding b.
+func NewBool(b bool) Value {
+	return Value{vt: Bool, v: b}
+}
+
+// NewUint32 returns a VT_UI4 value holding v.
+func NewUint32(v uint32) Value {
+	return Value{vt: UI4, v: v}
+}
+
+// NewInt64 returns a VT_I8 value holding v.
+func NewInt64(v int64) Value {
+	return Value{vt: I8, v: v}
+}
+
+// NewTime returns a VT_FILETIME value holding t.
+func NewTime(t time.Time) Value {
+	return Value{vt: FileTime, v: t}
+}
+
+// NewGUID returns a VT_CLSID value holding id.
+func NewGUID(id uuid.UUID) Value {
+	return Value{vt: CLSID, v: id}
+}
+
+// NewBlob returns a VT_BLOB value holding a copy of b.
+func NewBlob(b []byte) Value {
+	return Value{vt: Blob, v: bytes.Clone(b)}
+}
+
+// NewStrings returns a VT_VECTOR|VT_LPWSTR value holding s.
+func NewStrings(s []string) Value {
+	return Value{vt: Vector | LPWSTR, v: s}
+}
+
+// Type returns the type of the value.
+func (v Value) Type() VarType {
+	return v.vt
+}
+
+// IsEmpty returns true if the value is of type VT_EMPTY.
+func (v Value) IsEmpty() bool {
+	return v.vt == Empty
+}
+
+// IsRaw returns true if the value is held in its binary form because its
+// type is not supported.
+func (v Value) IsRaw() bool {
+	return v.raw != nil
+}
+
+// Interface returns the value as a Go value, or nil if the value is empty
+// or held in its binary form.
+func (v Value) Interface() any {
+	return v.v
+}
+
+// AsString returns the value of a VT_LPWSTR or VT_LPSTR value.
+func (v Value) AsString() (string, bool) {
+	s, ok := v.v.(string)
+	return s, ok
+}
+
+// AsStrings returns the value of a vector of VT_LPWSTR or VT_LPSTR
+// values.
+func (v Value) AsStrings() ([]string, bool) {
+	s, ok := v.v.([]string)
+	return s, ok
+}
+
+// AsBool returns the value of a VT_BOOL value.
+func (v Value) AsBool() (bool, bool) {
+	b, ok := v.v.(bool)
+	return b, ok
+}
+
+// AsUint32 returns the value of a VT_UI4 value.
+func (v Value) AsUint32() (uint32, bool) {
+	n, ok := v.v.(uint32)
+	return n, ok
+}
+
+// AsInt64 returns the value of a VT_I4, VT_UI4 or VT_I8 value as an
+// int64.
+func (v Value) AsInt64() (int64, bool) {
+	switch n := v.v.(type) {
+	case int32:
+		return int64(n), true
+	case uint32:
+		return int64(n), true
+	case int64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
+
+// AsUint64 returns the value of a VT_UI4 or VT_UI8 value as a uint64.
+func (v Value) AsUint64() (uint64, bool) {
+	switch n := v.v.(type) {
+	case uint32:
+		return uint64(n), true
+	case uint64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
+
+// AsTime returns the value of a VT_FILETIME value.
+func (v Value) AsTime() (time.Time, bool) {
+	t, ok := v.v.(time.Time)
+	return t, ok
+}
+
+// AsGUID returns the value of a VT_CLSID value.
+func (v Value) AsGUID() (uuid.UUID, bool) {
+	id, ok := v.v.(uuid.UUID)
+	return id, ok
+}
+
+// AsBytes returns the value of a VT_BLOB value.
+func (v Value) AsBytes() ([]byte, bool) {
+	b, ok := v.v.([]byte)
+	return b, ok
+}
+
+// String returns a string representation of the value for display.
+func (v Value) String() string {
+	switch {
+	case v.vt == Empty:
+		return "<empty>"
+	case v.raw != nil:
+		return fmt.Sprintf("%s %x", v.vt, v.raw[headerSize:])
+	}
+	return format(v.v)
+}
+
+// format returns a string representation of a Go value held by a Value.
+func format(v any) string {
+	switch x := v.(type) {
+	case string:
+		return x
+	case uuid.UUID:
+		return "{" + strings.ToUpper(x.String()) + "}"
+	case time.Time:
+		return x.Format(time.RFC3339Nano)
+	case []byte:
+		return hex.EncodeToString(x)
+	case []string:
+		return "[" + strings.Join(x, " ") + "]"
+	case []time.Time:
+		return formatSlice(x)
+	case []uuid.UUID:
+		return formatSlice(x)
+	default:
+		return fmt.Sprint(x)
+	}
+}
+
+// formatSlice returns a string representation of a slice of Go values
+// held by a Value.
+func formatSlice[T any](elems []T) string {
+	parts := make([]string, len(elems))
+	for i, elem := range elems {
+		parts[i] = format(elem)
+	}
+	return "[" + strings.Join(parts, " ") + "]"
+}
+
+// Equal returns true if v and other have the same type and binary
+// representation.
+func (v Value) Equal(other Value) bool {
+	a, errA := v.MarshalBinary()
+	b, errB := other.MarshalBinary()
+	return errA == nil && errB == nil && bytes.Equal(a, b)
+}
+
+// UnmarshalBinary parses a typed property value from data, which must
+// hold exactly one value.
+//
+// If the type is not supported, or if the value does not reproduce data
+// exactly when marshaled, the value is held in its binary form.
+func (v *Value) UnmarshalBinary(data []byte) error {
+	return v.UnmarshalANSI(data, nil)
+}
+
+// UnmarshalANSI parses a typed property value from data in the same
+// manner as UnmarshalBinary, but decodes VT_LPSTR strings with enc. The
+// value must be marshaled with the same encoding to reproduce data. A
+// nil encoding selects Windows-1252.
+func (v *Value) UnmarshalANSI(data []byte, enc encoding.Encoding) error {
+	if len(data) < headerSize {
+		return fmt.Errorf("the typed property value requires at least %d bytes, but only %d bytes are present", headerSize, len(data))
+	}
+	vt := VarType(binary.LittleEndian.Uint16(data[0:2]))
+	payload := data[headerSize:]
+
+	*v = Value{vt: vt, raw: bytes.Clone(data)}
+
+	decoded, n, err := decode(vt, payload, enc)
+	if err != nil || n != len(payload) {
+		return nil
+	}
+	typed := Value{vt: vt, v: decoded}
+	if encoded, err := typed.MarshalANSI(enc); err != nil || !bytes.Equal(encoded, data) {
+		return nil
+	}
+	*v = typed
+	return nil
+}
+
+// MarshalBinary returns the binary representation of the value.
+func (v Value) MarshalBinary() ([]byte, error) {
+	return v.MarshalANSI(nil)
+}
+
+// MarshalANSI returns the binary representation of the value, encoding
+// VT_LPSTR strings with enc. A nil encoding selects Windows-1252.
+func (v Value) MarshalANSI(enc encoding.Encoding) ([]byte, error) {
+	if v.raw != nil {
+		return bytes.Clone(v.raw), nil
+	}
+	data := make([]byte, headerSize)
+	binary.LittleEndian.PutUint16(data[0:2], uint16(v.vt))
+	return encode(data, v.vt, v.v, enc)
+}
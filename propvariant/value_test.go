@@ -0,0 +1,171 @@
+package propvariant_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gentlemanautomaton/winshell/propvariant"
+	"github.com/google/uuid"
+	"golang.org/x/text/encoding/japanese"
+)
+
+func ExampleFrom() {
+	value, err := propvariant.From([]string{"alpha", "beta"})
+	if err != nil {
+		panic(err)
+	}
+	data, err := value.MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(value.Type(), value)
+	fmt.Printf("%x\n", data)
+
+	// Output:
+	// VT_VECTOR|VT_LPWSTR [alpha beta]
+	// 1f100000020000000600000061006c00700068006100000005000000620065007400610000000000
+}
+
+func TestValueEncoding(t *testing.T) {
+	modified := time.Date(2026, 10, 16, 12, 30, 0, 0, time.UTC)
+	clsid := uuid.MustParse("00021401-0000-0000-C000-000000000046")
+
+	tests := []struct {
+		Name  string
+		Value propvariant.Value
+		Hex   string
+	}{
+		{"Empty", propvariant.Value{}, "00000000"},
+		{"Bool", propvariant.NewBool(true), "0b000000" + "ffff0000"},
+		{"UI4", propvariant.NewUint32(0x12345678), "13000000" + "78563412"},
+		{"I8", propvariant.NewInt64(-2), "14000000" + "feffffffffffffff"},
+		{"LPSTR", propvariant.NewANSIString("café"), "1e000000" + "05000000" + "636166e900" + "000000"},
+		{"LPWSTR", propvariant.NewString("Hi"), "1f000000" + "03000000" + "480069000000" + "0000"},
+		{"FileTime", propvariant.NewTime(modified), "40000000" + "0014900f6a5ddd01"},
+		{"CLSID", propvariant.NewGUID(clsid), "48000000" + "0114020000000000c000000000000046"},
+		{"Blob", propvariant.NewBlob([]byte{1, 2, 3, 4, 5}), "41000000" + "05000000" + "0102030405" + "000000"},
+		{"VectorBool", mustFrom(t, []bool{true, false, true}), "0b100000" + "03000000" + "ffff0000ffff" + "0000"},
+		{"VectorUI4", mustFrom(t, []uint32{1, 2}), "13100000" + "02000000" + "0100000002000000"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			data, err := test.Value.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := hex.EncodeToString(data); got != test.Hex {
+				t.Errorf("marshal:\n got %s\nwant %s", got, test.Hex)
+			}
+
+			var value propvariant.Value
+			if err := value.UnmarshalBinary(data); err != nil {
+				t.Fatal(err)
+			}
+			if value.IsRaw() {
+				t.Fatalf("the value was not decoded")
+			}
+			if !value.Equal(test.Value) {
+				t.Errorf("unmarshal: got %s %s, want %s %s", value.Type(), value, test.Value.Type(), test.Value)
+			}
+		})
+	}
+}
+
+func TestValueANSI(t *testing.T) {
+	const text = "テスト"
+	want := "1e000000" + "07000000" + "83658358836700" + "00"
+
+	data, err := propvariant.NewANSIString(text).MarshalANSI(japanese.ShiftJIS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(data); got != want {
+		t.Errorf("marshal:\n got %s\nwant %s", got, want)
+	}
+
+	var value propvariant.Value
+	if err := value.UnmarshalANSI(data, japanese.ShiftJIS); err != nil {
+		t.Fatal(err)
+	}
+	if s, ok := value.AsString(); !ok || s != text {
+		t.Errorf("unmarshal: got %s, want %q", value, text)
+	}
+
+	var western propvariant.Value
+	if err := western.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if s, _ := western.AsString(); s == text {
+		t.Errorf("value decoded as Windows-1252 unexpectedly matches the Shift JIS text")
+	}
+}
+
+func TestValueAccessors(t *testing.T) {
+	value, err := propvariant.New(propvariant.Vector|propvariant.LPSTR, []string{"a", "b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, ok := value.AsStrings(); !ok || len(s) != 2 {
+		t.Errorf("AsStrings: got %v (%t)", s, ok)
+	}
+	if _, ok := value.AsString(); ok {
+		t.Error("AsString succeeded on a vector")
+	}
+
+	if n, ok := propvariant.NewUint32(7).AsInt64(); !ok || n != 7 {
+		t.Errorf("AsInt64: got %d (%t)", n, ok)
+	}
+
+	if _, err := propvariant.New(propvariant.UI4, "seven"); err == nil {
+		t.Error("creating a VT_UI4 value from a string did not fail")
+	}
+	if _, err := propvariant.From(3.14); err == nil {
+		t.Error("creating a value from a float did not fail")
+	}
+}
+
+func TestValueUnsupported(t *testing.T) {
+	tests := []struct {
+		Name string
+		Data []byte
+	}{
+		// A VT_R8 value, which is not supported
+		{"R8", []byte{0x05, 0x00, 0x00, 0x00, 0x1F, 0x85, 0xEB, 0x51, 0xB8, 0x1E, 0x09, 0x40}},
+		{"R8Padding", []byte{0x05, 0x00, 0xAB, 0xCD, 0x1F, 0x85, 0xEB, 0x51, 0xB8, 0x1E, 0x09, 0x40}},
+		// A VT_I4 value with nonzero padding, which cannot be reproduced
+		{"I4Padding", []byte{0x03, 0x00, 0x01, 0x02, 0x2A, 0x00, 0x00, 0x00}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var value propvariant.Value
+			if err := value.UnmarshalBinary(test.Data); err != nil {
+				t.Fatal(err)
+			}
+			if !value.IsRaw() || value.Type() != propvariant.VarType(test.Data[0]) {
+				t.Errorf("got %s (raw %t)", value.Type(), value.IsRaw())
+			}
+
+			encoded, err := value.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(encoded, test.Data) {
+				t.Errorf("got %x, want %x", encoded, test.Data)
+			}
+		})
+	}
+}
+
+func mustFrom(t *testing.T, v any) propvariant.Value {
+	t.Helper()
+	value, err := propvariant.From(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return value
+}
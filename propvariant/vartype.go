@@ -0,0 +1,68 @@
+package propvariant
+
+import "fmt"
+
+// VarType identifies the type of a property value. The Vector flag may
+// be combined with a scalar type to form a vector of values of that type.
+type VarType uint16
+
+// Property value types.
+const (
+	Empty    VarType = 0x0000 // VT_EMPTY
+	I4       VarType = 0x0003 // VT_I4
+	Bool     VarType = 0x000B // VT_BOOL
+	UI4      VarType = 0x0013 // VT_UI4
+	I8       VarType = 0x0014 // VT_I8
+	UI8      VarType = 0x0015 // VT_UI8
+	LPSTR    VarType = 0x001E // VT_LPSTR
+	LPWSTR   VarType = 0x001F // VT_LPWSTR
+	FileTime VarType = 0x0040 // VT_FILETIME
+	Blob     VarType = 0x0041 // VT_BLOB
+	CLSID    VarType = 0x0048 // VT_CLSID
+
+	// Vector is a flag that indicates a vector of values.
+	Vector VarType = 0x1000 // VT_VECTOR
+)
+
+// typeMask extracts the scalar type from a type.
+const typeMask = 0x0FFF
+
+var varTypeNames = map[VarType]string{
+	Empty:    "VT_EMPTY",
+	I4:       "VT_I4",
+	Bool:     "VT_BOOL",
+	UI4:      "VT_UI4",
+	I8:       "VT_I8",
+	UI8:      "VT_UI8",
+	LPSTR:    "VT_LPSTR",
+	LPWSTR:   "VT_LPWSTR",
+	FileTime: "VT_FILETIME",
+	Blob:     "VT_BLOB",
+	CLSID:    "VT_CLSID",
+}
+
+// IsVector returns true if the type is a vector of values.
+func (t VarType) IsVector() bool {
+	return t&Vector != 0
+}
+
+// Elem returns the scalar type of the type, without the Vector flag.
+func (t VarType) Elem() VarType {
+	return t & typeMask
+}
+
+// String returns the name of the type, such as VT_LPWSTR or
+// VT_VECTOR|VT_LPWSTR.
+func (t VarType) String() string {
+	name, ok := varTypeNames[t.Elem()]
+	if !ok {
+		name = fmt.Sprintf("%#04x", uint16(t.Elem()))
+		if !t.IsVector() {
+			return "VarType(" + name + ")"
+		}
+	}
+	if t.IsVector() {
+		return "VT_VECTOR|" + name
+	}
+	return name
+}
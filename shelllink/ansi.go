@@ -0,0 +1,30 @@
+package shelllink
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// readANSIZ reads a null-terminated string encoded in the code page from
+// data at the given offset.
+func readANSIZ(data []byte, offset uint32, cp CodePage) (string, error) {
+	if int(offset) >= len(data) {
+		return "", fmt.Errorf("string offset %d is beyond the end of its %d byte structure", offset, len(data))
+	}
+	b := data[offset:]
+	end := bytes.IndexByte(b, 0)
+	if end < 0 {
+		return "", fmt.Errorf("string at offset %d is not null-terminated", offset)
+	}
+	return cp.Decode(b[:end])
+}
+
+// encodeANSIZ returns s as a null-terminated string encoded in the code
+// page.
+func encodeANSIZ(s string, cp CodePage) ([]byte, error) {
+	encoded, err := cp.Encode(s)
+	if err != nil {
+		return nil, err
+	}
+	return append(encoded, 0), nil
+}
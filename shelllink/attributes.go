@@ -0,0 +1,79 @@
+package shelllink
+
+import "fmt"
+
+// FileAttributes describe the file system attributes of a link target.
+//
+// https://docs.microsoft.com/en-us/windows/win32/fileio/file-attribute-constants
+type FileAttributes uint32
+
+// File attributes.
+const (
+	FileAttributeReadOnly          FileAttributes = 0x00000001
+	FileAttributeHidden            FileAttributes = 0x00000002
+	FileAttributeSystem            FileAttributes = 0x00000004
+	FileAttributeDirectory         FileAttributes = 0x00000010
+	FileAttributeArchive           FileAttributes = 0x00000020
+	FileAttributeNormal            FileAttributes = 0x00000080
+	FileAttributeTemporary         FileAttributes = 0x00000100
+	FileAttributeSparseFile        FileAttributes = 0x00000200
+	FileAttributeReparsePoint      FileAttributes = 0x00000400
+	FileAttributeCompressed        FileAttributes = 0x00000800
+	FileAttributeOffline           FileAttributes = 0x00001000
+	FileAttributeNotContentIndexed FileAttributes = 0x00002000
+	FileAttributeEncrypted         FileAttributes = 0x00004000
+)
+
+var fileAttributeNames = []bitName{
+	{uint32(FileAttributeReadOnly), "FileAttributeReadOnly"},
+	{uint32(FileAttributeHidden), "FileAttributeHidden"},
+	{uint32(FileAttributeSystem), "FileAttributeSystem"},
+	{uint32(FileAttributeDirectory), "FileAttributeDirectory"},
+	{uint32(FileAttributeArchive), "FileAttributeArchive"},
+	{uint32(FileAttributeNormal), "FileAttributeNormal"},
+	{uint32(FileAttributeTemporary), "FileAttributeTemporary"},
+	{uint32(FileAttributeSparseFile), "FileAttributeSparseFile"},
+	{uint32(FileAttributeReparsePoint), "FileAttributeReparsePoint"},
+	{uint32(FileAttributeCompressed), "FileAttributeCompressed"},
+	{uint32(FileAttributeOffline), "FileAttributeOffline"},
+	{uint32(FileAttributeNotContentIndexed), "FileAttributeNotContentIndexed"},
+	{uint32(FileAttributeEncrypted), "FileAttributeEncrypted"},
+}
+
+// Has returns true if all of the given attributes are set.
+func (a FileAttributes) Has(attrs FileAttributes) bool {
+	return a&attrs == attrs
+}
+
+// Set sets the given attributes.
+func (a *FileAttributes) Set(attrs FileAttributes) {
+	*a |= attrs
+}
+
+// Clear clears the given attributes.
+func (a *FileAttributes) Clear(attrs FileAttributes) {
+	*a &^= attrs
+}
+
+// String returns a string representation of the attributes, such as
+// "FileAttributeReadOnly|FileAttributeArchive".
+func (a FileAttributes) String() string {
+	return formatBits(uint32(a), fileAttributeNames)
+}
+
+// MarshalText implements encoding.TextMarshaler. It returns the same
+// representation as String.
+func (a FileAttributes) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts the
+// representation returned by String.
+func (a *FileAttributes) UnmarshalText(text []byte) error {
+	v, err := parseBits(string(text), fileAttributeNames)
+	if err != nil {
+		return fmt.Errorf("invalid file attributes: %v", err)
+	}
+	*a = FileAttributes(v)
+	return nil
+}
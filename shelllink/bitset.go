@@ -0,0 +1,64 @@
+package shelllink
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// bitName associates a name with a single bit of a bitset.
+type bitName struct {
+	bit  uint32
+	name string
+}
+
+// formatBits returns a string representation of v, which is formed by
+// joining the names of each of its bits with a vertical bar. Bits without
+// a name are included as a single hexadecimal value at the end. A value
+// of zero is returned as "0".
+func formatBits(v uint32, names []bitName) string {
+	if v == 0 {
+		return "0"
+	}
+
+	var parts []string
+	for _, n := range names {
+		if v&n.bit != 0 {
+			parts = append(parts, n.name)
+			v &^= n.bit
+		}
+	}
+	if v != 0 {
+		parts = append(parts, fmt.Sprintf("%#x", v))
+	}
+	return strings.Join(parts, "|")
+}
+
+// parseBits parses a string produced by formatBits. Names are matched
+// without regard to case. Numeric values are also accepted in any base
+// understood by strconv.ParseUint.
+func parseBits(s string, names []bitName) (uint32, error) {
+	var v uint32
+	for _, part := range strings.Split(s, "|") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if n, err := strconv.ParseUint(part, 0, 32); err == nil {
+			v |= uint32(n)
+			continue
+		}
+		found := false
+		for _, n := range names {
+			if strings.EqualFold(part, n.name) {
+				v |= n.bit
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("unrecognized value \"%s\"", part)
+		}
+	}
+	return v, nil
+}
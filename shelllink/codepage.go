@@ -0,0 +1,124 @@
+package shelllink
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/korean"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
+)
+
+// CodePage identifies a Windows ANSI code page. It determines how strings
+// are encoded in shell links that do not store them as Unicode.
+//
+// The zero value is treated as Windows-1252, which is the ANSI code page
+// of most western locales.
+type CodePage uint16
+
+// Supported code pages.
+const (
+	CodePageDefault     CodePage = 0    // Windows-1252
+	CodePageOEMUS       CodePage = 437  // OEM United States
+	CodePageOEMLatin1   CodePage = 850  // OEM Multilingual Latin 1
+	CodePageOEMCyrillic CodePage = 866  // OEM Russian
+	CodePageThai        CodePage = 874  // Windows-874 (Thai)
+	CodePageShiftJIS    CodePage = 932  // Shift JIS (Japanese)
+	CodePageGBK         CodePage = 936  // GBK (Simplified Chinese)
+	CodePageKorean      CodePage = 949  // Unified Hangul Code (Korean)
+	CodePageBig5        CodePage = 950  // Big5 (Traditional Chinese)
+	CodePage1250        CodePage = 1250 // Windows-1250 (Central European)
+	CodePage1251        CodePage = 1251 // Windows-1251 (Cyrillic)
+	CodePage1252        CodePage = 1252 // Windows-1252 (Western European)
+	CodePage1253        CodePage = 1253 // Windows-1253 (Greek)
+	CodePage1254        CodePage = 1254 // Windows-1254 (Turkish)
+	CodePage1255        CodePage = 1255 // Windows-1255 (Hebrew)
+	CodePage1256        CodePage = 1256 // Windows-1256 (Arabic)
+	CodePage1257        CodePage = 1257 // Windows-1257 (Baltic)
+	CodePage1258        CodePage = 1258 // Windows-1258 (Vietnamese)
+)
+
+var codePageEncodings = map[CodePage]encoding.Encoding{
+	CodePageDefault:     charmap.Windows1252,
+	CodePageOEMUS:       charmap.CodePage437,
+	CodePageOEMLatin1:   charmap.CodePage850,
+	CodePageOEMCyrillic: charmap.CodePage866,
+	CodePageThai:        charmap.Windows874,
+	CodePageShiftJIS:    japanese.ShiftJIS,
+	CodePageGBK:         simplifiedchinese.GBK,
+	CodePageKorean:      korean.EUCKR,
+	CodePageBig5:        traditionalchinese.Big5,
+	CodePage1250:        charmap.Windows1250,
+	CodePage1251:        charmap.Windows1251,
+	CodePage1252:        charmap.Windows1252,
+	CodePage1253:        charmap.Windows1253,
+	CodePage1254:        charmap.Windows1254,
+	CodePage1255:        charmap.Windows1255,
+	CodePage1256:        charmap.Windows1256,
+	CodePage1257:        charmap.Windows1257,
+	CodePage1258:        charmap.Windows1258,
+}
+
+// Supported returns true if the code page is supported.
+func (cp CodePage) Supported() bool {
+	_, ok := codePageEncodings[cp]
+	return ok
+}
+
+// String returns a string representation of the code page.
+func (cp CodePage) String() string {
+	if cp == CodePageDefault {
+		return "CP1252"
+	}
+	return fmt.Sprintf("CP%d", uint16(cp))
+}
+
+// encoding returns the text encoding of the code page.
+func (cp CodePage) encoding() (encoding.Encoding, error) {
+	enc, ok := codePageEncodings[cp]
+	if !ok {
+		return nil, fmt.Errorf("code page %d is not supported", uint16(cp))
+	}
+	return enc, nil
+}
+
+// Decode converts b from the code page to a Go string. Byte sequences
+// that are invalid in the code page are replaced with the Unicode
+// replacement character.
+func (cp CodePage) Decode(b []byte) (string, error) {
+	enc, err := cp.encoding()
+	if err != nil {
+		return "", err
+	}
+	s, err := enc.NewDecoder().Bytes(b)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode %s string: %v", cp, err)
+	}
+	return string(s), nil
+}
+
+// Encode converts s to the code page. Characters that cannot be
+// represented in the code page are replaced with a question mark, as
+// Windows does.
+func (cp CodePage) Encode(s string) ([]byte, error) {
+	enc, err := cp.encoding()
+	if err != nil {
+		return nil, err
+	}
+	encoder := enc.NewEncoder()
+	out := make([]byte, 0, len(s))
+	var buf [utf8.UTFMax]byte
+	for _, r := range s {
+		n := utf8.EncodeRune(buf[:], r)
+		b, err := encoder.Bytes(buf[:n])
+		if err != nil {
+			out = append(out, '?')
+			continue
+		}
+		out = append(out, b...)
+	}
+	return out, nil
+}
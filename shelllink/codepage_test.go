@@ -0,0 +1,149 @@
+package shelllink_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gentlemanautomaton/winshell/propkey"
+	"github.com/gentlemanautomaton/winshell/propvariant"
+	"github.com/gentlemanautomaton/winshell/shelllink"
+)
+
+func TestLinkCodePage(t *testing.T) {
+	const name = "テスト"
+	shiftJIS := []byte{0x83, 0x65, 0x83, 0x58, 0x83, 0x67}
+
+	source := shelllink.Link{
+		Header: shelllink.Header{ShowCommand: shelllink.ShowNormal},
+		LinkInfo: &shelllink.LinkInfo{
+			VolumeID:      &shelllink.VolumeID{DriveType: shelllink.DriveFixed},
+			LocalBasePath: `C:\テスト\a.txt`,
+		},
+		StringData: shelllink.StringData{Name: name},
+		CodePage:   shelllink.CodePageShiftJIS,
+	}
+
+	data, err := source.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(data, shiftJIS) {
+		t.Fatalf("encoded link does not contain the Shift JIS name: %x", data)
+	}
+
+	link := shelllink.Link{CodePage: shelllink.CodePageShiftJIS}
+	if err := link.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if link.StringData.Name != name {
+		t.Errorf("name: got %q, want %q", link.StringData.Name, name)
+	}
+	if got, want := link.LinkInfo.Path(), source.LinkInfo.LocalBasePath; got != want {
+		t.Errorf("path: got %q, want %q", got, want)
+	}
+
+	var western shelllink.Link
+	if err := western.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if western.StringData.Name == name {
+		t.Errorf("name decoded as Windows-1252 unexpectedly matches the Shift JIS name")
+	}
+}
+
+func TestExtraDataCodePage(t *testing.T) {
+	const text = "テスト"
+	shiftJIS := []byte{0x83, 0x65, 0x83, 0x58, 0x83, 0x67}
+
+	tests := []struct {
+		Name  string
+		Block shelllink.DataBlock
+		ANSI  func(shelllink.DataBlock) string
+		Want  string
+	}{
+		{
+			Name:  "EnvironmentVariableData",
+			Block: shelllink.NewEnvironmentVariableData(`%ProgramFiles%\` + text + `\app.exe`),
+			ANSI:  func(b shelllink.DataBlock) string { return b.(*shelllink.EnvironmentVariableData).Target },
+			Want:  `%ProgramFiles%\` + text + `\app.exe`,
+		},
+		{
+			Name:  "IconEnvironmentData",
+			Block: shelllink.NewIconEnvironmentData(`%ProgramFiles%\` + text + `\app.ico`),
+			ANSI:  func(b shelllink.DataBlock) string { return b.(*shelllink.IconEnvironmentData).Target },
+			Want:  `%ProgramFiles%\` + text + `\app.ico`,
+		},
+		{
+			Name:  "DarwinData",
+			Block: &shelllink.DarwinData{ID: "w_1^VX!!!!!!!!!MKKSk" + text + "<", IDUnicode: "w_1^VX!!!!!!!!!MKKSk" + text + "<"},
+			ANSI:  func(b shelllink.DataBlock) string { return b.(*shelllink.DarwinData).ID },
+			Want:  "w_1^VX!!!!!!!!!MKKSk" + text + "<",
+		},
+		{
+			Name:  "PropertyStoreData",
+			Block: propertyStoreBlock(propkey.Comment, propvariant.NewANSIString(text)),
+			ANSI: func(b shelllink.DataBlock) string {
+				value, _ := b.(*shelllink.PropertyStoreData).Store.Get(propkey.Comment)
+				s, _ := value.AsString()
+				return s
+			},
+			Want: text,
+		},
+		{
+			Name:  "TrackerData",
+			Block: &shelllink.TrackerData{MachineID: text},
+			ANSI:  func(b shelllink.DataBlock) string { return b.(*shelllink.TrackerData).MachineID },
+			Want:  text,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			source := shelllink.Link{
+				ExtraData: shelllink.ExtraData{test.Block},
+				CodePage:  shelllink.CodePageShiftJIS,
+			}
+			data, err := source.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Contains(data, shiftJIS) {
+				t.Fatalf("encoded link does not contain Shift JIS text: %x", data)
+			}
+
+			link := shelllink.Link{CodePage: shelllink.CodePageShiftJIS}
+			if err := link.UnmarshalBinary(data); err != nil {
+				t.Fatal(err)
+			}
+			block := link.ExtraData.Get(test.Block.Signature())
+			if block == nil {
+				t.Fatalf("the block was not decoded: %#v", link.ExtraData)
+			}
+			if got := test.ANSI(block); got != test.Want {
+				t.Errorf("ANSI text: got %q, want %q", got, test.Want)
+			}
+		})
+	}
+}
+
+// propertyStoreBlock returns a property store data block that holds a
+// single property.
+func propertyStoreBlock(key propkey.PropertyKey, value propvariant.Value) *shelllink.PropertyStoreData {
+	block := new(shelllink.PropertyStoreData)
+	block.Store.Set(key, value)
+	return block
+}
+
+func TestCodePageEncodeUnrepresentable(t *testing.T) {
+	b, err := shelllink.CodePage1252.Encode("a€bテ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []byte{'a', 0x80, 'b', '?'}; !bytes.Equal(b, want) {
+		t.Errorf("got %x, want %x", b, want)
+	}
+
+	if _, err := shelllink.CodePage(1).Decode([]byte("a")); err == nil {
+		t.Errorf("decoding with an unsupported code page did not fail")
+	}
+}
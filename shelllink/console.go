@@ -0,0 +1,192 @@
+package shelllink
+
+import "encoding/binary"
+
+// consoleDataSize is the number of bytes in a ConsoleDataBlock, excluding
+// its size and signature.
+const consoleDataSize = 0xCC - blockHeaderSize
+
+// consoleFEDataSize is the number of bytes in a ConsoleFEDataBlock,
+// excluding its size and signature.
+const consoleFEDataSize = 0x0C - blockHeaderSize
+
+// ConsoleCoord is a pair of console coordinates, measured in character
+// cells.
+type ConsoleCoord struct {
+	X int16
+	Y int16
+}
+
+// ColorRef is an RGB color in the COLORREF format, 0x00BBGGRR.
+type ColorRef uint32
+
+// RGB returns a ColorRef with the given red, green and blue components.
+func RGB(r, g, b uint8) ColorRef {
+	return ColorRef(uint32(r) | uint32(g)<<8 | uint32(b)<<16)
+}
+
+// R returns the red component of the color.
+func (c ColorRef) R() uint8 { return uint8(c) }
+
+// G returns the green component of the color.
+func (c ColorRef) G() uint8 { return uint8(c >> 8) }
+
+// B returns the blue component of the color.
+func (c ColorRef) B() uint8 { return uint8(c >> 16) }
+
+// ConsoleData holds display settings for a console window launched by a
+// shell link. It corresponds to a ConsoleDataBlock.
+//
+// The fill attributes select foreground and background colors from the
+// color table. The low four bits hold the foreground index and the next
+// four bits hold the background index.
+type ConsoleData struct {
+	FillAttributes      uint16
+	PopupFillAttributes uint16
+	ScreenBufferSize    ConsoleCoord
+	WindowSize          ConsoleCoord
+	WindowOrigin        ConsoleCoord
+
+	// FontSize holds the font width in X and the font height in Y, in
+	// pixels. TrueType fonts usually specify a width of zero.
+	FontSize   ConsoleCoord
+	FontFamily uint32
+	FontWeight uint32
+	FaceName   string
+
+	// CursorSize is the size of the cursor as a percentage of the
+	// character cell, from 0 to 100. Values of 25 or less are small.
+	CursorSize   uint32
+	FullScreen   bool
+	QuickEdit    bool
+	InsertMode   bool
+	AutoPosition bool
+
+	HistoryBufferSize      uint32
+	NumberOfHistoryBuffers uint32
+	HistoryNoDup           bool
+
+	ColorTable [16]ColorRef
+
+	unused1 uint32
+	unused2 uint32
+}
+
+// Signature returns the signature of the block.
+func (c ConsoleData) Signature() Signature {
+	return ConsoleDataBlock
+}
+
+// UnmarshalBinary parses console data from data, which excludes the
+// block's size and signature.
+func (c *ConsoleData) UnmarshalBinary(data []byte) error {
+	if err := checkBlockSize(ConsoleDataBlock, data, consoleDataSize); err != nil {
+		return err
+	}
+
+	u16 := func(offset int) uint16 { return binary.LittleEndian.Uint16(data[offset:]) }
+	u32 := func(offset int) uint32 { return binary.LittleEndian.Uint32(data[offset:]) }
+	coord := func(offset int) ConsoleCoord {
+		return ConsoleCoord{X: int16(u16(offset)), Y: int16(u16(offset + 2))}
+	}
+
+	*c = ConsoleData{
+		FillAttributes:         u16(0),
+		PopupFillAttributes:    u16(2),
+		ScreenBufferSize:       coord(4),
+		WindowSize:             coord(8),
+		WindowOrigin:           coord(12),
+		unused1:                u32(16),
+		unused2:                u32(20),
+		FontSize:               coord(24),
+		FontFamily:             u32(28),
+		FontWeight:             u32(32),
+		FaceName:               decodeFixedUnicode(data[36:100]),
+		CursorSize:             u32(100),
+		FullScreen:             u32(104) != 0,
+		QuickEdit:              u32(108) != 0,
+		InsertMode:             u32(112) != 0,
+		AutoPosition:           u32(116) != 0,
+		HistoryBufferSize:      u32(120),
+		NumberOfHistoryBuffers: u32(124),
+		HistoryNoDup:           u32(128) != 0,
+	}
+	for i := range c.ColorTable {
+		c.ColorTable[i] = ColorRef(u32(132 + i*4))
+	}
+
+	return nil
+}
+
+// MarshalBinary returns the binary representation of the console data,
+// excluding the block's size and signature.
+func (c ConsoleData) MarshalBinary() ([]byte, error) {
+	data := make([]byte, consoleDataSize)
+
+	putU16 := func(offset int, v uint16) { binary.LittleEndian.PutUint16(data[offset:], v) }
+	putU32 := func(offset int, v uint32) { binary.LittleEndian.PutUint32(data[offset:], v) }
+	putCoord := func(offset int, v ConsoleCoord) {
+		putU16(offset, uint16(v.X))
+		putU16(offset+2, uint16(v.Y))
+	}
+	putBool := func(offset int, v bool) {
+		if v {
+			putU32(offset, 1)
+		}
+	}
+
+	putU16(0, c.FillAttributes)
+	putU16(2, c.PopupFillAttributes)
+	putCoord(4, c.ScreenBufferSize)
+	putCoord(8, c.WindowSize)
+	putCoord(12, c.WindowOrigin)
+	putU32(16, c.unused1)
+	putU32(20, c.unused2)
+	putCoord(24, c.FontSize)
+	putU32(28, c.FontFamily)
+	putU32(32, c.FontWeight)
+	if err := putFixedUnicode(data[36:100], c.FaceName); err != nil {
+		return nil, err
+	}
+	putU32(100, c.CursorSize)
+	putBool(104, c.FullScreen)
+	putBool(108, c.QuickEdit)
+	putBool(112, c.InsertMode)
+	putBool(116, c.AutoPosition)
+	putU32(120, c.HistoryBufferSize)
+	putU32(124, c.NumberOfHistoryBuffers)
+	putBool(128, c.HistoryNoDup)
+	for i, color := range c.ColorTable {
+		putU32(132+i*4, uint32(color))
+	}
+
+	return data, nil
+}
+
+// ConsoleFEData specifies the code page used to display text in a
+// console window launched by a shell link. It corresponds to a
+// ConsoleFEDataBlock.
+type ConsoleFEData struct {
+	CodePage uint32
+}
+
+// Signature returns the signature of the block.
+func (c ConsoleFEData) Signature() Signature {
+	return ConsoleFEDataBlock
+}
+
+// UnmarshalBinary parses console code page data from data, which excludes
+// the block's size and signature.
+func (c *ConsoleFEData) UnmarshalBinary(data []byte) error {
+	if err := checkBlockSize(ConsoleFEDataBlock, data, consoleFEDataSize); err != nil {
+		return err
+	}
+	c.CodePage = binary.LittleEndian.Uint32(data[0:4])
+	return nil
+}
+
+// MarshalBinary returns the binary representation of the console code
+// page data, excluding the block's size and signature.
+func (c ConsoleFEData) MarshalBinary() ([]byte, error) {
+	return binary.LittleEndian.AppendUint32(nil, c.CodePage), nil
+}
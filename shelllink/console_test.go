@@ -0,0 +1,70 @@
+package shelllink_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gentlemanautomaton/winshell/shelllink"
+)
+
+func TestConsoleDataRoundTrip(t *testing.T) {
+	console := &shelllink.ConsoleData{
+		FillAttributes:         0x07,
+		PopupFillAttributes:    0xF5,
+		ScreenBufferSize:       shelllink.ConsoleCoord{X: 120, Y: 9001},
+		WindowSize:             shelllink.ConsoleCoord{X: 120, Y: 30},
+		FontSize:               shelllink.ConsoleCoord{Y: 16},
+		FontFamily:             0x36,
+		FontWeight:             400,
+		FaceName:               "Consolas",
+		CursorSize:             25,
+		QuickEdit:              true,
+		InsertMode:             true,
+		AutoPosition:           true,
+		HistoryBufferSize:      50,
+		NumberOfHistoryBuffers: 4,
+		ColorTable: [16]shelllink.ColorRef{
+			shelllink.RGB(12, 12, 12), shelllink.RGB(0, 55, 218), shelllink.RGB(19, 161, 14), shelllink.RGB(58, 150, 221),
+			shelllink.RGB(197, 15, 31), shelllink.RGB(136, 23, 152), shelllink.RGB(193, 156, 0), shelllink.RGB(204, 204, 204),
+			shelllink.RGB(118, 118, 118), shelllink.RGB(59, 120, 255), shelllink.RGB(22, 198, 12), shelllink.RGB(97, 214, 214),
+			shelllink.RGB(231, 72, 86), shelllink.RGB(180, 0, 158), shelllink.RGB(249, 241, 165), shelllink.RGB(242, 242, 242),
+		},
+	}
+	fe := &shelllink.ConsoleFEData{CodePage: 65001}
+
+	source := shelllink.Link{
+		Header:     shelllink.Header{Flags: shelllink.IsUnicode, ShowCommand: shelllink.ShowNormal},
+		StringData: shelllink.StringData{RelativePath: `..\..\Windows\System32\cmd.exe`},
+		ExtraData:  shelllink.ExtraData{console, fe},
+	}
+
+	data, err := source.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var link shelllink.Link
+	if err := link.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if len(link.ExtraData) != 2 {
+		t.Fatalf("got %d extra data blocks, want 2", len(link.ExtraData))
+	}
+	if got, ok := link.ExtraData[0].(*shelllink.ConsoleData); !ok {
+		t.Errorf("first block: got %T, want %T", link.ExtraData[0], console)
+	} else if !reflect.DeepEqual(got, console) {
+		t.Errorf("console data:\n got %+v\nwant %+v", got, console)
+	}
+	if got, ok := link.ExtraData[1].(*shelllink.ConsoleFEData); !ok {
+		t.Errorf("second block: got %T, want %T", link.ExtraData[1], fe)
+	} else if *got != *fe {
+		t.Errorf("console FE data: got %+v, want %+v", got, fe)
+	}
+}
+
+func TestConsoleDataFaceNameTooLong(t *testing.T) {
+	console := shelllink.ConsoleData{FaceName: "A Font Face Name That Is Far Too Long"}
+	if _, err := console.MarshalBinary(); err == nil {
+		t.Errorf("marshaling a face name longer than 31 characters did not fail")
+	}
+}
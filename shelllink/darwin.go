@@ -0,0 +1,209 @@
+package shelllink
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/gentlemanautomaton/winshell/internal/guid"
+	"github.com/google/uuid"
+)
+
+// DarwinData holds the Windows Installer descriptor of an advertised
+// shortcut. It corresponds to a DarwinDataBlock.
+//
+// The descriptor is stored as both ANSI and Unicode. The ANSI form is
+// encoded with the link's code page. Each form is limited to 259
+// characters.
+//
+// Link.MarshalBinary sets the HasDarwinID flag when a link has this
+// block, and clears it when the link does not.
+type DarwinData struct {
+	ID        string
+	IDUnicode string
+
+	fields fixedPaths
+}
+
+// NewDarwinData returns a Darwin data block for the given descriptor,
+// with both its ANSI and Unicode forms populated.
+func NewDarwinData(descriptor DarwinDescriptor) (*DarwinData, error) {
+	id, err := descriptor.Compress()
+	if err != nil {
+		return nil, err
+	}
+	return &DarwinData{ID: id, IDUnicode: id}, nil
+}
+
+// Signature returns the signature of the block.
+func (d DarwinData) Signature() Signature {
+	return DarwinDataBlock
+}
+
+// Descriptor parses the Windows Installer descriptor held by the block,
+// preferring its Unicode form.
+func (d DarwinData) Descriptor() (DarwinDescriptor, error) {
+	return ParseDarwinDescriptor(prefer(d.IDUnicode, d.ID))
+}
+
+// UnmarshalBinary parses Darwin data from data, which excludes the
+// block's size and signature. The ANSI form of the descriptor is decoded
+// as Windows-1252.
+func (d *DarwinData) UnmarshalBinary(data []byte) error {
+	return d.unmarshal(data, CodePageDefault)
+}
+
+// unmarshal parses Darwin data from data, decoding the ANSI form of the
+// descriptor with cp.
+func (d *DarwinData) unmarshal(data []byte, cp CodePage) error {
+	var fields fixedPaths
+	if err := fields.unmarshal(DarwinDataBlock, data, cp); err != nil {
+		return err
+	}
+	*d = DarwinData{ID: fields.ansi, IDUnicode: fields.unicode, fields: fields}
+	return nil
+}
+
+// MarshalBinary returns the binary representation of the Darwin data,
+// excluding the block's size and signature. The ANSI form of the
+// descriptor is encoded as Windows-1252.
+func (d DarwinData) MarshalBinary() ([]byte, error) {
+	return d.marshal(CodePageDefault)
+}
+
+// marshal returns the binary representation of the Darwin data, encoding
+// the ANSI form of the descriptor with cp.
+func (d DarwinData) marshal(cp CodePage) ([]byte, error) {
+	return d.fields.marshal(d.ID, d.IDUnicode, cp)
+}
+
+// DarwinDescriptor identifies the Windows Installer product, feature and
+// component that own an advertised shortcut.
+//
+// ComponentCode is uuid.Nil when the descriptor does not name a
+// component, in which case Windows Installer uses the feature's key
+// path.
+type DarwinDescriptor struct {
+	ProductCode   uuid.UUID
+	Feature       string
+	ComponentCode uuid.UUID
+}
+
+// Compressed descriptor delimiters.
+const (
+	darwinComponent   = '>'
+	darwinNoComponent = '<'
+)
+
+// ParseDarwinDescriptor parses a compressed Windows Installer descriptor,
+// as stored in a DarwinDataBlock. The descriptor begins with a product
+// code and a feature name. It ends with either '>' and a component code,
+// or with '<' if it does not name a component. The product and component
+// codes are compressed GUIDs of 20 characters each.
+func ParseDarwinDescriptor(s string) (DarwinDescriptor, error) {
+	if len(s) < compressedGUIDSize+1 {
+		return DarwinDescriptor{}, fmt.Errorf("the darwin descriptor \"%s\" is too short", s)
+	}
+
+	product, err := decompressGUID(s[:compressedGUIDSize])
+	if err != nil {
+		return DarwinDescriptor{}, fmt.Errorf("invalid darwin descriptor product code: %v", err)
+	}
+	rest := s[compressedGUIDSize:]
+
+	if rest[len(rest)-1] == darwinNoComponent {
+		return DarwinDescriptor{ProductCode: product, Feature: rest[:len(rest)-1]}, nil
+	}
+
+	delimiter := len(rest) - compressedGUIDSize - 1
+	if delimiter < 0 || rest[delimiter] != darwinComponent {
+		return DarwinDescriptor{}, fmt.Errorf("the darwin descriptor \"%s\" lacks a component delimiter", s)
+	}
+	component, err := decompressGUID(rest[delimiter+1:])
+	if err != nil {
+		return DarwinDescriptor{}, fmt.Errorf("invalid darwin descriptor component code: %v", err)
+	}
+
+	return DarwinDescriptor{
+		ProductCode:   product,
+		Feature:       rest[:delimiter],
+		ComponentCode: component,
+	}, nil
+}
+
+// Compress returns the descriptor in its compressed form, as stored in a
+// DarwinDataBlock.
+func (d DarwinDescriptor) Compress() (string, error) {
+	if strings.ContainsAny(d.Feature, string([]rune{darwinComponent, darwinNoComponent})) {
+		return "", fmt.Errorf("the darwin descriptor feature \"%s\" contains a delimiter", d.Feature)
+	}
+	var b strings.Builder
+	b.WriteString(compressGUID(d.ProductCode))
+	b.WriteString(d.Feature)
+	if d.ComponentCode == uuid.Nil {
+		b.WriteByte(darwinNoComponent)
+	} else {
+		b.WriteByte(darwinComponent)
+		b.WriteString(compressGUID(d.ComponentCode))
+	}
+	return b.String(), nil
+}
+
+// String returns the descriptor in the form "{product}feature{component}".
+func (d DarwinDescriptor) String() string {
+	s := "{" + strings.ToUpper(d.ProductCode.String()) + "}" + d.Feature
+	if d.ComponentCode != uuid.Nil {
+		s += "{" + strings.ToUpper(d.ComponentCode.String()) + "}"
+	}
+	return s
+}
+
+// compressedGUIDSize is the number of characters in a compressed GUID.
+const compressedGUIDSize = 20
+
+// darwinAlphabet holds the characters used by compressed GUIDs, in order
+// of their value.
+const darwinAlphabet = "!$%&'()*+,-.0123456789=?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[]^_`abcdefghijklmnopqrstuvwxyz{|}"
+
+// decompressGUID decodes a GUID compressed by Windows Installer. Each
+// group of five characters encodes a 32 bit value in base 85, starting
+// with its least significant digit. The four values hold the in-memory
+// representation of the GUID.
+func decompressGUID(s string) (uuid.UUID, error) {
+	if len(s) != compressedGUIDSize {
+		return uuid.Nil, fmt.Errorf("the compressed GUID \"%s\" is not %d characters long", s, compressedGUIDSize)
+	}
+
+	var b [guid.Size]byte
+	for group := 0; group < 4; group++ {
+		var value uint64
+		for i := 4; i >= 0; i-- {
+			c := s[group*5+i]
+			digit := strings.IndexByte(darwinAlphabet, c)
+			if digit < 0 {
+				return uuid.Nil, fmt.Errorf("the compressed GUID \"%s\" contains an invalid character '%c'", s, c)
+			}
+			value = value*85 + uint64(digit)
+		}
+		if value > 0xFFFFFFFF {
+			return uuid.Nil, fmt.Errorf("the compressed GUID \"%s\" holds an out of range value", s)
+		}
+		binary.LittleEndian.PutUint32(b[group*4:], uint32(value))
+	}
+	return guid.Decode(b[:]), nil
+}
+
+// compressGUID encodes a GUID in the compressed form used by Windows
+// Installer.
+func compressGUID(id uuid.UUID) string {
+	b := guid.Bytes(id)
+	out := make([]byte, 0, compressedGUIDSize)
+	for group := 0; group < 4; group++ {
+		value := binary.LittleEndian.Uint32(b[group*4:])
+		for i := 0; i < 5; i++ {
+			out = append(out, darwinAlphabet[value%85])
+			value /= 85
+		}
+	}
+	return string(out)
+}
@@ -0,0 +1,79 @@
+package shelllink_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gentlemanautomaton/winshell/shelllink"
+	"github.com/google/uuid"
+)
+
+func ExampleParseDarwinDescriptor() {
+	descriptor, err := shelllink.ParseDarwinDescriptor("w_1^VX!!!!!!!!!MKKSkEXCELFiles<")
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(descriptor)
+
+	// Output: {91120000-0030-0000-0000-0000000FF1CE}EXCELFiles
+}
+
+func TestDarwinData(t *testing.T) {
+	want := shelllink.DarwinDescriptor{
+		ProductCode:   uuid.MustParse("91120000-0030-0000-0000-0000000FF1CE"),
+		Feature:       "EXCELFiles",
+		ComponentCode: uuid.MustParse("0638C49D-BB8B-4CD1-B191-051E8F325736"),
+	}
+
+	block, err := shelllink.NewDarwinData(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	source := shelllink.Link{
+		Header:    shelllink.Header{Flags: shelllink.IsUnicode},
+		ExtraData: shelllink.ExtraData{block},
+	}
+	data, err := source.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var link shelllink.Link
+	if err := link.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if !link.Header.Flags.Has(shelllink.HasDarwinID) {
+		t.Errorf("the HasDarwinID flag is not set")
+	}
+
+	darwin, ok := link.ExtraData[0].(*shelllink.DarwinData)
+	if !ok {
+		t.Fatalf("got %T, want %T", link.ExtraData[0], darwin)
+	}
+	if len(darwin.IDUnicode) != 20+len(want.Feature)+1+20 {
+		t.Errorf("unexpected compressed descriptor %q", darwin.IDUnicode)
+	}
+
+	got, err := darwin.Descriptor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestParseDarwinDescriptorInvalid(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"w_1^VX!!!!!!!!!MKKSk",
+		"w_1^VX!!!!!!!!!MKKSkEXCELFiles",
+		"w_1^VX!!!!!!!!!MKKS~EXCELFiles<",
+		"w_1^VX!!!!!!!!!MKKSkEXCELFiles>tooshort",
+	} {
+		if _, err := shelllink.ParseDarwinDescriptor(s); err == nil {
+			t.Errorf("%q: parsing did not fail", s)
+		}
+	}
+}
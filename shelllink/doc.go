@@ -1,4 +1,6 @@
-// +build windows
-
-// Package shelllink facilitates creation of shell links (shortcuts).
+// Package shelllink facilitates creation and parsing of shell links
+// (shortcuts).
+//
+// Shell links are described by the Shell Link Binary File Format:
+// https://docs.microsoft.com/en-us/openspecs/windows_protocols/ms-shllink/16cb4ca1-9339-4d0c-a68d-bf1d6cc0f943
 package shelllink
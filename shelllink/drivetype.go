@@ -0,0 +1,35 @@
+package shelllink
+
+import "fmt"
+
+// DriveType identifies the type of drive that a link target is stored on.
+type DriveType uint32
+
+// Drive types.
+const (
+	DriveUnknown   DriveType = 0 // DRIVE_UNKNOWN
+	DriveNoRootDir DriveType = 1 // DRIVE_NO_ROOT_DIR
+	DriveRemovable DriveType = 2 // DRIVE_REMOVABLE
+	DriveFixed     DriveType = 3 // DRIVE_FIXED
+	DriveRemote    DriveType = 4 // DRIVE_REMOTE
+	DriveCDROM     DriveType = 5 // DRIVE_CDROM
+	DriveRAMDisk   DriveType = 6 // DRIVE_RAMDISK
+)
+
+var driveTypeNames = map[DriveType]string{
+	DriveUnknown:   "DRIVE_UNKNOWN",
+	DriveNoRootDir: "DRIVE_NO_ROOT_DIR",
+	DriveRemovable: "DRIVE_REMOVABLE",
+	DriveFixed:     "DRIVE_FIXED",
+	DriveRemote:    "DRIVE_REMOTE",
+	DriveCDROM:     "DRIVE_CDROM",
+	DriveRAMDisk:   "DRIVE_RAMDISK",
+}
+
+// String returns the name of the drive type.
+func (t DriveType) String() string {
+	if name, ok := driveTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("DriveType(%d)", uint32(t))
+}
@@ -0,0 +1,208 @@
+package shelllink
+
+import "bytes"
+
+// The sizes of the fixed string fields used by environment, icon and
+// Darwin data blocks.
+const (
+	maxPath             = 260
+	fixedPathANSISize   = maxPath
+	fixedPathSize       = maxPath * 2
+	fixedPathsBlockSize = fixedPathANSISize + fixedPathSize
+)
+
+// fixedPaths holds a string stored in both the ANSI and Unicode fixed
+// size fields of a data block. The original field contents are retained
+// so that blocks with garbage after their terminators can be marshaled
+// without loss.
+type fixedPaths struct {
+	ansi    string
+	unicode string
+	raw     []byte
+}
+
+// unmarshal parses the ANSI and Unicode fields from data. The ANSI field
+// is decoded with cp.
+func (f *fixedPaths) unmarshal(sig Signature, data []byte, cp CodePage) error {
+	if err := checkBlockSize(sig, data, fixedPathsBlockSize); err != nil {
+		return err
+	}
+	ansi, err := decodeFixedANSI(data[:fixedPathANSISize], cp)
+	if err != nil {
+		return err
+	}
+	*f = fixedPaths{
+		ansi:    ansi,
+		unicode: decodeFixedUnicode(data[fixedPathANSISize:]),
+		raw:     bytes.Clone(data),
+	}
+	return nil
+}
+
+// marshal returns the binary representation of the ANSI and Unicode
+// fields, encoding the ANSI field with cp. Each field is written from its
+// original contents if its string has not changed.
+func (f fixedPaths) marshal(ansi, unicode string, cp CodePage) ([]byte, error) {
+	data := make([]byte, fixedPathsBlockSize)
+	if f.raw != nil && ansi == f.ansi {
+		copy(data[:fixedPathANSISize], f.raw)
+	} else if err := putFixedANSI(data[:fixedPathANSISize], ansi, cp); err != nil {
+		return nil, err
+	}
+	if f.raw != nil && unicode == f.unicode {
+		copy(data[fixedPathANSISize:], f.raw[fixedPathANSISize:])
+	} else if err := putFixedUnicode(data[fixedPathANSISize:], unicode); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// EnvironmentVariableData holds the path to a link target in a form that
+// includes environment variables, such as %ProgramFiles%\App\app.exe. It
+// corresponds to an EnvironmentVariableDataBlock.
+//
+// The path is stored as both ANSI and Unicode. The ANSI form is encoded
+// with the link's code page and characters that cannot be represented
+// are replaced with question marks. Each form is limited to 259
+// characters.
+//
+// Link.MarshalBinary sets the HasExpString flag when a link has this
+// block, and clears it when the link does not.
+type EnvironmentVariableData struct {
+	Target        string
+	TargetUnicode string
+
+	fields fixedPaths
+}
+
+// NewEnvironmentVariableData returns an environment variable data block
+// for the given target, with both its ANSI and Unicode forms populated.
+func NewEnvironmentVariableData(target string) *EnvironmentVariableData {
+	return &EnvironmentVariableData{Target: target, TargetUnicode: target}
+}
+
+// Signature returns the signature of the block.
+func (e EnvironmentVariableData) Signature() Signature {
+	return EnvironmentVariableDataBlock
+}
+
+// Path returns the target, preferring its Unicode form.
+func (e EnvironmentVariableData) Path() string {
+	return prefer(e.TargetUnicode, e.Target)
+}
+
+// UnmarshalBinary parses environment variable data from data, which
+// excludes the block's size and signature. The ANSI form of the path is
+// decoded as Windows-1252.
+func (e *EnvironmentVariableData) UnmarshalBinary(data []byte) error {
+	return e.unmarshal(data, CodePageDefault)
+}
+
+// unmarshal parses environment variable data from data, decoding the ANSI
+// form of the path with cp.
+func (e *EnvironmentVariableData) unmarshal(data []byte, cp CodePage) error {
+	var fields fixedPaths
+	if err := fields.unmarshal(EnvironmentVariableDataBlock, data, cp); err != nil {
+		return err
+	}
+	*e = EnvironmentVariableData{Target: fields.ansi, TargetUnicode: fields.unicode, fields: fields}
+	return nil
+}
+
+// MarshalBinary returns the binary representation of the environment
+// variable data, excluding the block's size and signature. The ANSI form
+// of the path is encoded as Windows-1252.
+func (e EnvironmentVariableData) MarshalBinary() ([]byte, error) {
+	return e.marshal(CodePageDefault)
+}
+
+// marshal returns the binary representation of the environment variable
+// data, encoding the ANSI form of the path with cp.
+func (e EnvironmentVariableData) marshal(cp CodePage) ([]byte, error) {
+	return e.fields.marshal(e.Target, e.TargetUnicode, cp)
+}
+
+// IconEnvironmentData holds the path to a link's icon in a form that
+// includes environment variables, such as %SystemRoot%\system32\shell32.dll.
+// It corresponds to an IconEnvironmentDataBlock.
+//
+// The path is stored as both ANSI and Unicode. The ANSI form is encoded
+// with the link's code page and characters that cannot be represented
+// are replaced with question marks. Each form is limited to 259
+// characters.
+//
+// Link.MarshalBinary sets the HasExpIcon flag when a link has this
+// block, and clears it when the link does not.
+type IconEnvironmentData struct {
+	Target        string
+	TargetUnicode string
+
+	fields fixedPaths
+}
+
+// NewIconEnvironmentData returns an icon environment data block for the
+// given icon path, with both its ANSI and Unicode forms populated.
+func NewIconEnvironmentData(target string) *IconEnvironmentData {
+	return &IconEnvironmentData{Target: target, TargetUnicode: target}
+}
+
+// Signature returns the signature of the block.
+func (e IconEnvironmentData) Signature() Signature {
+	return IconEnvironmentDataBlock
+}
+
+// Path returns the icon path, preferring its Unicode form.
+func (e IconEnvironmentData) Path() string {
+	return prefer(e.TargetUnicode, e.Target)
+}
+
+// UnmarshalBinary parses icon environment data from data, which excludes
+// the block's size and signature. The ANSI form of the path is decoded
+// as Windows-1252.
+func (e *IconEnvironmentData) UnmarshalBinary(data []byte) error {
+	return e.unmarshal(data, CodePageDefault)
+}
+
+// unmarshal parses icon environment data from data, decoding the ANSI
+// form of the path with cp.
+func (e *IconEnvironmentData) unmarshal(data []byte, cp CodePage) error {
+	var fields fixedPaths
+	if err := fields.unmarshal(IconEnvironmentDataBlock, data, cp); err != nil {
+		return err
+	}
+	*e = IconEnvironmentData{Target: fields.ansi, TargetUnicode: fields.unicode, fields: fields}
+	return nil
+}
+
+// MarshalBinary returns the binary representation of the icon
+// environment data, excluding the block's size and signature. The ANSI
+// form of the path is encoded as Windows-1252.
+func (e IconEnvironmentData) MarshalBinary() ([]byte, error) {
+	return e.marshal(CodePageDefault)
+}
+
+// marshal returns the binary representation of the icon environment
+// data, encoding the ANSI form of the path with cp.
+func (e IconEnvironmentData) marshal(cp CodePage) ([]byte, error) {
+	return e.fields.marshal(e.Target, e.TargetUnicode, cp)
+}
+
+// SetEnvironmentTarget stores target, a path that includes environment
+// variables, in the link's environment variable data block. It replaces
+// any existing block. Windows expands the path when the link is resolved,
+// which allows the link to survive differences in the location of
+// folders such as %ProgramFiles%.
+func (link *Link) SetEnvironmentTarget(target string) {
+	link.ExtraData.Set(NewEnvironmentVariableData(target))
+	link.Header.Flags.Set(HasExpString)
+}
+
+// SetEnvironmentIcon stores location, a path that includes environment
+// variables, as the link's icon. It replaces any existing icon
+// environment data block and sets the link's icon location and index.
+func (link *Link) SetEnvironmentIcon(location string, index int32) {
+	link.ExtraData.Set(NewIconEnvironmentData(location))
+	link.Header.Flags.Set(HasExpIcon)
+	link.Header.IconIndex = index
+	link.StringData.IconLocation = location
+}
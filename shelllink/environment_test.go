@@ -0,0 +1,77 @@
+package shelllink_test
+
+import (
+	"testing"
+
+	"github.com/gentlemanautomaton/winshell/shelllink"
+)
+
+func TestLinkSetEnvironmentTarget(t *testing.T) {
+	var source shelllink.Link
+	source.Header.Flags = shelllink.IsUnicode
+	source.SetEnvironmentTarget(`%ProgramFiles%\App\app.exe`)
+	source.SetEnvironmentIcon(`%ProgramFiles%\App\app.exe`, 2)
+
+	data, err := source.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var link shelllink.Link
+	if err := link.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+
+	if flags := link.Header.Flags; !flags.Has(shelllink.HasExpString | shelllink.HasExpIcon | shelllink.HasIconLocation) {
+		t.Errorf("flags: got %s", flags)
+	}
+	if len(link.ExtraData) != 2 {
+		t.Fatalf("got %d extra data blocks, want 2", len(link.ExtraData))
+	}
+
+	env, ok := link.ExtraData[0].(*shelllink.EnvironmentVariableData)
+	if !ok {
+		t.Fatalf("first block: got %T", link.ExtraData[0])
+	}
+	if env.Target != `%ProgramFiles%\App\app.exe` || env.TargetUnicode != env.Target {
+		t.Errorf("environment target: got %+v", env)
+	}
+
+	icon, ok := link.ExtraData[1].(*shelllink.IconEnvironmentData)
+	if !ok {
+		t.Fatalf("second block: got %T", link.ExtraData[1])
+	}
+	if icon.Path() != `%ProgramFiles%\App\app.exe` || link.Header.IconIndex != 2 {
+		t.Errorf("icon: got %q index %d", icon.Path(), link.Header.IconIndex)
+	}
+}
+
+func TestEnvironmentVariableDataPreservesPadding(t *testing.T) {
+	data, err := shelllink.NewEnvironmentVariableData(`%windir%\notepad.exe`).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Windows does not always clear the bytes after the terminator
+	data[200] = 0xCC
+	data[700] = 0xCC
+
+	var env shelllink.EnvironmentVariableData
+	if err := env.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := env.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if encoded[200] != 0xCC || encoded[700] != 0xCC {
+		t.Errorf("padding bytes were not preserved")
+	}
+
+	env.TargetUnicode = `%windir%\system32\notepad.exe`
+	if encoded, err = env.MarshalBinary(); err != nil {
+		t.Fatal(err)
+	}
+	if encoded[200] != 0xCC || encoded[700] != 0 {
+		t.Errorf("changing the unicode target did not rewrite only its field")
+	}
+}
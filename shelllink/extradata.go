@@ -0,0 +1,277 @@
+package shelllink
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+// Signature identifies the type of an extra data block.
+type Signature uint32
+
+// Extra data block signatures.
+const (
+	EnvironmentVariableDataBlock Signature = 0xA0000001
+	ConsoleDataBlock             Signature = 0xA0000002
+	TrackerDataBlock             Signature = 0xA0000003
+	ConsoleFEDataBlock           Signature = 0xA0000004
+	SpecialFolderDataBlock       Signature = 0xA0000005
+	DarwinDataBlock              Signature = 0xA0000006
+	IconEnvironmentDataBlock     Signature = 0xA0000007
+	ShimDataBlock                Signature = 0xA0000008
+	PropertyStoreDataBlock       Signature = 0xA0000009
+	KnownFolderDataBlock         Signature = 0xA000000B
+	VistaAndAboveIDListDataBlock Signature = 0xA000000C
+)
+
+// blockHeaderSize is the number of bytes in the size and signature that
+// begin each extra data block.
+const blockHeaderSize = 8
+
+var signatureNames = map[Signature]string{
+	EnvironmentVariableDataBlock: "EnvironmentVariableDataBlock",
+	ConsoleDataBlock:             "ConsoleDataBlock",
+	TrackerDataBlock:             "TrackerDataBlock",
+	ConsoleFEDataBlock:           "ConsoleFEDataBlock",
+	SpecialFolderDataBlock:       "SpecialFolderDataBlock",
+	DarwinDataBlock:              "DarwinDataBlock",
+	IconEnvironmentDataBlock:     "IconEnvironmentDataBlock",
+	ShimDataBlock:                "ShimDataBlock",
+	PropertyStoreDataBlock:       "PropertyStoreDataBlock",
+	KnownFolderDataBlock:         "KnownFolderDataBlock",
+	VistaAndAboveIDListDataBlock: "VistaAndAboveIDListDataBlock",
+}
+
+// String returns the name of the signature.
+func (sig Signature) String() string {
+	if name, ok := signatureNames[sig]; ok {
+		return name
+	}
+	return fmt.Sprintf("Signature(%#x)", uint32(sig))
+}
+
+// DataBlock is a block of data within the extra data section of a shell
+// link.
+//
+// The binary representation of a data block excludes its size and
+// signature, which are written by the extra data section.
+type DataBlock interface {
+	Signature() Signature
+	MarshalBinary() ([]byte, error)
+}
+
+// blockUnmarshaler is a data block that can be decoded from its binary
+// representation.
+type blockUnmarshaler interface {
+	DataBlock
+	UnmarshalBinary(data []byte) error
+}
+
+// ansiBlock is a data block that holds ANSI strings. Its internal
+// methods decode and encode those strings with a given code page, while
+// UnmarshalBinary and MarshalBinary use Windows-1252.
+type ansiBlock interface {
+	unmarshal(data []byte, cp CodePage) error
+	marshal(cp CodePage) ([]byte, error)
+}
+
+// unmarshalBlock decodes block from data, using cp for ANSI strings if
+// the block holds them.
+func unmarshalBlock(block blockUnmarshaler, data []byte, cp CodePage) error {
+	if block, ok := block.(ansiBlock); ok {
+		return block.unmarshal(data, cp)
+	}
+	return block.UnmarshalBinary(data)
+}
+
+// marshalBlock encodes block, using cp for ANSI strings if the block
+// holds them.
+func marshalBlock(block DataBlock, cp CodePage) ([]byte, error) {
+	if block, ok := block.(ansiBlock); ok {
+		return block.marshal(cp)
+	}
+	return block.MarshalBinary()
+}
+
+// blockTypes holds functions that return new, empty data blocks for
+// each of the signatures that have a typed representation.
+var blockTypes = map[Signature]func() blockUnmarshaler{
+	EnvironmentVariableDataBlock: func() blockUnmarshaler { return new(EnvironmentVariableData) },
+	ConsoleDataBlock:             func() blockUnmarshaler { return new(ConsoleData) },
+	TrackerDataBlock:             func() blockUnmarshaler { return new(TrackerData) },
+	ConsoleFEDataBlock:           func() blockUnmarshaler { return new(ConsoleFEData) },
+	SpecialFolderDataBlock:       func() blockUnmarshaler { return new(SpecialFolderData) },
+	DarwinDataBlock:              func() blockUnmarshaler { return new(DarwinData) },
+	IconEnvironmentDataBlock:     func() blockUnmarshaler { return new(IconEnvironmentData) },
+	ShimDataBlock:                func() blockUnmarshaler { return new(ShimData) },
+	PropertyStoreDataBlock:       func() blockUnmarshaler { return new(PropertyStoreData) },
+	KnownFolderDataBlock:         func() blockUnmarshaler { return new(KnownFolderData) },
+	VistaAndAboveIDListDataBlock: func() blockUnmarshaler { return new(VistaAndAboveIDListData) },
+}
+
+// decodeBlock returns a typed data block for the given signature and
+// data, which excludes the block's size and signature. ANSI strings are
+// decoded with cp.
+//
+// If the signature does not have a typed representation, or if the typed
+// block does not reproduce data exactly when marshaled, the block is
+// returned as a RawBlock so that it can be marshaled without loss.
+func decodeBlock(sig Signature, data []byte, cp CodePage) DataBlock {
+	raw := RawBlock{BlockSignature: sig, Data: append([]byte(nil), data...)}
+
+	fn, ok := blockTypes[sig]
+	if !ok {
+		return raw
+	}
+
+	typed := fn()
+	if err := unmarshalBlock(typed, data, cp); err != nil {
+		return raw
+	}
+	if encoded, err := marshalBlock(typed, cp); err != nil || !bytes.Equal(encoded, data) {
+		return raw
+	}
+	return typed
+}
+
+// checkBlockSize returns an error if data does not hold exactly size
+// bytes.
+func checkBlockSize(sig Signature, data []byte, size int) error {
+	if len(data) != size {
+		return fmt.Errorf("the %s requires %d bytes of data, but %d bytes are present", sig, size, len(data))
+	}
+	return nil
+}
+
+// RawBlock is an extra data block held in its binary form. It is used for
+// blocks without a typed representation and for blocks that could not be
+// decoded without loss.
+type RawBlock struct {
+	BlockSignature Signature
+	Data           []byte
+}
+
+// Signature returns the signature of the block.
+func (b RawBlock) Signature() Signature {
+	return b.BlockSignature
+}
+
+// MarshalBinary returns the data held by the block.
+func (b RawBlock) MarshalBinary() ([]byte, error) {
+	return append([]byte(nil), b.Data...), nil
+}
+
+// ExtraData is an ordered sequence of extra data blocks.
+//
+// Blocks are decoded into typed values when their signature is
+// recognized and they can be reproduced exactly. All other blocks,
+// including those with unrecognized signatures, are held as RawBlock
+// values. Every block is marshaled in its original position, so links
+// can be edited without losing data written by other software or by
+// future versions of Windows.
+//
+// https://docs.microsoft.com/en-us/openspecs/windows_protocols/ms-shllink/c41e062d-f764-4f13-bd4f-ea812ab9a4d1
+type ExtraData []DataBlock
+
+// Get returns the first block with the given signature, or nil if the
+// extra data does not contain one.
+func (extra ExtraData) Get(sig Signature) DataBlock {
+	for _, block := range extra {
+		if block.Signature() == sig {
+			return block
+		}
+	}
+	return nil
+}
+
+// Set replaces the first block with the same signature as block, keeping
+// its position. If there is no such block, block is appended.
+func (extra *ExtraData) Set(block DataBlock) {
+	for i, existing := range *extra {
+		if existing.Signature() == block.Signature() {
+			(*extra)[i] = block
+			return
+		}
+	}
+	*extra = append(*extra, block)
+}
+
+// Remove removes all blocks with the given signature. The order of the
+// remaining blocks is preserved.
+func (extra *ExtraData) Remove(sig Signature) {
+	kept := (*extra)[:0]
+	for _, block := range *extra {
+		if block.Signature() != sig {
+			kept = append(kept, block)
+		}
+	}
+	clear((*extra)[len(kept):])
+	*extra = kept
+}
+
+// flags returns the link flags that indicate the presence of blocks
+// within the extra data.
+func (extra ExtraData) flags() (flags LinkFlags) {
+	for _, block := range extra {
+		switch block.Signature() {
+		case EnvironmentVariableDataBlock:
+			flags |= HasExpString
+		case DarwinDataBlock:
+			flags |= HasDarwinID
+		case IconEnvironmentDataBlock:
+			flags |= HasExpIcon
+		case ShimDataBlock:
+			flags |= RunWithShimLayer
+		}
+	}
+	return flags
+}
+
+// unmarshal parses extra data blocks from data until it encounters a
+// terminal block, decoding ANSI strings with cp. It returns the number of
+// bytes consumed.
+func (extra *ExtraData) unmarshal(data []byte, cp CodePage) (n int, err error) {
+	*extra = nil
+	for {
+		if len(data)-n < 4 {
+			// Some writers omit the terminal block entirely
+			if n == len(data) {
+				return n, nil
+			}
+			return n, fmt.Errorf("the shell link extra data is truncated at offset %d", n)
+		}
+
+		size := int(binary.LittleEndian.Uint32(data[n : n+4]))
+		if size < 4 {
+			// This is the terminal block
+			return n + 4, nil
+		}
+		if size < blockHeaderSize {
+			return n, fmt.Errorf("the shell link extra data block at offset %d declares an invalid size of %d bytes", n, size)
+		}
+		if len(data)-n < size {
+			return n, fmt.Errorf("the shell link extra data block at offset %d declares a size of %d bytes, but only %d bytes remain", n, size, len(data)-n)
+		}
+
+		sig := Signature(binary.LittleEndian.Uint32(data[n+4 : n+8]))
+		*extra = append(*extra, decodeBlock(sig, data[n+blockHeaderSize:n+size], cp))
+
+		n += size
+	}
+}
+
+// marshal returns the binary representation of the extra data blocks,
+// followed by a terminal block. ANSI strings are encoded with cp.
+func (extra ExtraData) marshal(cp CodePage) ([]byte, error) {
+	var data []byte
+	for _, block := range extra {
+		body, err := marshalBlock(block, cp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s: %v", block.Signature(), err)
+		}
+		data = binary.LittleEndian.AppendUint32(data, uint32(blockHeaderSize+len(body)))
+		data = binary.LittleEndian.AppendUint32(data, uint32(block.Signature()))
+		data = append(data, body...)
+	}
+	return binary.LittleEndian.AppendUint32(data, 0), nil
+}
@@ -0,0 +1,112 @@
+package shelllink_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gentlemanautomaton/winshell/shelllink"
+)
+
+func TestExtraDataPreservesUnknownBlocks(t *testing.T) {
+	vendor := shelllink.RawBlock{BlockSignature: 0xA0000077, Data: []byte("vendor data!")}
+	malformed := shelllink.RawBlock{BlockSignature: shelllink.ConsoleFEDataBlock, Data: []byte{1, 2}}
+
+	source := shelllink.Link{
+		Header:     shelllink.Header{Flags: shelllink.IsUnicode},
+		StringData: shelllink.StringData{Arguments: "/old"},
+		ExtraData: shelllink.ExtraData{
+			&shelllink.ConsoleFEData{CodePage: 437},
+			vendor,
+			malformed,
+			shelllink.NewEnvironmentVariableData(`%windir%\notepad.exe`),
+		},
+	}
+
+	data, err := source.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var link shelllink.Link
+	if err := link.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(link.ExtraData) != 4 {
+		t.Fatalf("got %d blocks, want 4", len(link.ExtraData))
+	}
+	if raw, ok := link.ExtraData[1].(shelllink.RawBlock); !ok || raw.BlockSignature != vendor.BlockSignature || !bytes.Equal(raw.Data, vendor.Data) {
+		t.Errorf("second block: got %#v, want %#v", link.ExtraData[1], vendor)
+	}
+	if raw, ok := link.ExtraData[2].(shelllink.RawBlock); !ok || !bytes.Equal(raw.Data, malformed.Data) {
+		t.Errorf("third block: a malformed known block was not kept raw: %#v", link.ExtraData[2])
+	}
+
+	// Edit the link in place and make sure only the arguments change
+	link.StringData.Arguments = "/new"
+	link.ExtraData.Set(&shelllink.ConsoleFEData{CodePage: 65001})
+
+	edited, err := link.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	source.StringData.Arguments = "/new"
+	source.ExtraData[0] = &shelllink.ConsoleFEData{CodePage: 65001}
+	want, err := source.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(edited, want) {
+		t.Errorf("edited link:\n got %x\nwant %x", edited, want)
+	}
+
+	link.ExtraData.Remove(shelllink.ConsoleFEDataBlock)
+	if len(link.ExtraData) != 2 || link.ExtraData.Get(0xA0000077) == nil || link.ExtraData.Get(shelllink.EnvironmentVariableDataBlock) == nil {
+		t.Errorf("removing console FE blocks affected other blocks: %#v", link.ExtraData)
+	}
+}
+
+func TestExtraDataFlags(t *testing.T) {
+	tests := []struct {
+		Name  string
+		Block shelllink.DataBlock
+		Flag  shelllink.LinkFlags
+	}{
+		{"EnvironmentVariableData", shelllink.NewEnvironmentVariableData(`%windir%\notepad.exe`), shelllink.HasExpString},
+		{"IconEnvironmentData", shelllink.NewIconEnvironmentData(`%windir%\notepad.exe`), shelllink.HasExpIcon},
+		{"DarwinData", &shelllink.DarwinData{ID: "darwin", IDUnicode: "darwin"}, shelllink.HasDarwinID},
+		{"ShimData", &shelllink.ShimData{LayerName: "RUNASADMIN"}, shelllink.RunWithShimLayer},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			link := shelllink.Link{Header: shelllink.Header{Flags: shelllink.IsUnicode}}
+			link.ExtraData.Set(test.Block)
+			if flags := roundTripFlags(t, link); flags != shelllink.IsUnicode|test.Flag {
+				t.Errorf("with the block: got flags %s, want %s", flags, shelllink.IsUnicode|test.Flag)
+			}
+
+			link.Header.Flags.Set(test.Flag)
+			link.ExtraData.Remove(test.Block.Signature())
+			if flags := roundTripFlags(t, link); flags != shelllink.IsUnicode {
+				t.Errorf("without the block: got flags %s, want %s", flags, shelllink.IsUnicode)
+			}
+		})
+	}
+}
+
+// roundTripFlags marshals and unmarshals link, and returns the flags of
+// the result.
+func roundTripFlags(t *testing.T, link shelllink.Link) shelllink.LinkFlags {
+	t.Helper()
+	data, err := link.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded shelllink.Link
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	return decoded.Header.Flags
+}
@@ -0,0 +1,57 @@
+package shelllink
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/gentlemanautomaton/winshell/internal/utf16le"
+)
+
+// decodeFixedUnicode decodes a null-terminated UTF-16 string stored in a
+// fixed-size field. If the field lacks a terminator the entire field is
+// decoded.
+func decodeFixedUnicode(field []byte) string {
+	if s, _, ok := utf16le.DecodeZ(field); ok {
+		return s
+	}
+	return utf16le.Decode(field)
+}
+
+// putFixedUnicode writes s to a fixed-size field as a null-terminated
+// UTF-16 string. The remainder of the field is zeroed. It returns an
+// error if s and its terminator do not fit within the field.
+func putFixedUnicode(field []byte, s string) error {
+	encoded := utf16le.EncodeZ(s)
+	if len(encoded) > len(field) {
+		return fmt.Errorf("the string \"%s\" requires %d bytes, which exceeds its %d byte field", s, len(encoded), len(field))
+	}
+	n := copy(field, encoded)
+	clear(field[n:])
+	return nil
+}
+
+// decodeFixedANSI decodes a null-terminated string stored in a fixed-size
+// field in the given code page. If the field lacks a terminator the entire
+// field is decoded.
+func decodeFixedANSI(field []byte, cp CodePage) (string, error) {
+	if end := bytes.IndexByte(field, 0); end >= 0 {
+		field = field[:end]
+	}
+	return cp.Decode(field)
+}
+
+// putFixedANSI writes s to a fixed-size field as a null-terminated string
+// encoded in the given code page. The remainder of the field is zeroed.
+// It returns an error if s and its terminator do not fit within the field.
+func putFixedANSI(field []byte, s string, cp CodePage) error {
+	encoded, err := encodeANSIZ(s, cp)
+	if err != nil {
+		return err
+	}
+	if len(encoded) > len(field) {
+		return fmt.Errorf("the string \"%s\" requires %d bytes, which exceeds its %d byte field", s, len(encoded), len(field))
+	}
+	n := copy(field, encoded)
+	clear(field[n:])
+	return nil
+}
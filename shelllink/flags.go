@@ -0,0 +1,104 @@
+package shelllink
+
+import "fmt"
+
+// LinkFlags specify the presence of optional structures within a shell
+// link, as well as various properties of the link.
+//
+// https://docs.microsoft.com/en-us/openspecs/windows_protocols/ms-shllink/ae350202-3ba9-4790-9e9e-98935f4ee5af
+type LinkFlags uint32
+
+// Shell link flags.
+const (
+	HasLinkTargetIDList         LinkFlags = 0x00000001
+	HasLinkInfo                 LinkFlags = 0x00000002
+	HasName                     LinkFlags = 0x00000004
+	HasRelativePath             LinkFlags = 0x00000008
+	HasWorkingDir               LinkFlags = 0x00000010
+	HasArguments                LinkFlags = 0x00000020
+	HasIconLocation             LinkFlags = 0x00000040
+	IsUnicode                   LinkFlags = 0x00000080
+	ForceNoLinkInfo             LinkFlags = 0x00000100
+	HasExpString                LinkFlags = 0x00000200
+	RunInSeparateProcess        LinkFlags = 0x00000400
+	HasDarwinID                 LinkFlags = 0x00001000
+	RunAsUser                   LinkFlags = 0x00002000
+	HasExpIcon                  LinkFlags = 0x00004000
+	NoPidlAlias                 LinkFlags = 0x00008000
+	RunWithShimLayer            LinkFlags = 0x00020000
+	ForceNoLinkTrack            LinkFlags = 0x00040000
+	EnableTargetMetadata        LinkFlags = 0x00080000
+	DisableLinkPathTracking     LinkFlags = 0x00100000
+	DisableKnownFolderTracking  LinkFlags = 0x00200000
+	DisableKnownFolderAlias     LinkFlags = 0x00400000
+	AllowLinkToLink             LinkFlags = 0x00800000
+	UnaliasOnSave               LinkFlags = 0x01000000
+	PreferEnvironmentPath       LinkFlags = 0x02000000
+	KeepLocalIDListForUNCTarget LinkFlags = 0x04000000
+)
+
+var linkFlagNames = []bitName{
+	{uint32(HasLinkTargetIDList), "HasLinkTargetIDList"},
+	{uint32(HasLinkInfo), "HasLinkInfo"},
+	{uint32(HasName), "HasName"},
+	{uint32(HasRelativePath), "HasRelativePath"},
+	{uint32(HasWorkingDir), "HasWorkingDir"},
+	{uint32(HasArguments), "HasArguments"},
+	{uint32(HasIconLocation), "HasIconLocation"},
+	{uint32(IsUnicode), "IsUnicode"},
+	{uint32(ForceNoLinkInfo), "ForceNoLinkInfo"},
+	{uint32(HasExpString), "HasExpString"},
+	{uint32(RunInSeparateProcess), "RunInSeparateProcess"},
+	{uint32(HasDarwinID), "HasDarwinID"},
+	{uint32(RunAsUser), "RunAsUser"},
+	{uint32(HasExpIcon), "HasExpIcon"},
+	{uint32(NoPidlAlias), "NoPidlAlias"},
+	{uint32(RunWithShimLayer), "RunWithShimLayer"},
+	{uint32(ForceNoLinkTrack), "ForceNoLinkTrack"},
+	{uint32(EnableTargetMetadata), "EnableTargetMetadata"},
+	{uint32(DisableLinkPathTracking), "DisableLinkPathTracking"},
+	{uint32(DisableKnownFolderTracking), "DisableKnownFolderTracking"},
+	{uint32(DisableKnownFolderAlias), "DisableKnownFolderAlias"},
+	{uint32(AllowLinkToLink), "AllowLinkToLink"},
+	{uint32(UnaliasOnSave), "UnaliasOnSave"},
+	{uint32(PreferEnvironmentPath), "PreferEnvironmentPath"},
+	{uint32(KeepLocalIDListForUNCTarget), "KeepLocalIDListForUNCTarget"},
+}
+
+// Has returns true if all of the given flags are set.
+func (f LinkFlags) Has(flags LinkFlags) bool {
+	return f&flags == flags
+}
+
+// Set sets the given flags.
+func (f *LinkFlags) Set(flags LinkFlags) {
+	*f |= flags
+}
+
+// Clear clears the given flags.
+func (f *LinkFlags) Clear(flags LinkFlags) {
+	*f &^= flags
+}
+
+// String returns a string representation of the flags, such as
+// "HasLinkTargetIDList|IsUnicode|RunAsUser".
+func (f LinkFlags) String() string {
+	return formatBits(uint32(f), linkFlagNames)
+}
+
+// MarshalText implements encoding.TextMarshaler. It returns the same
+// representation as String.
+func (f LinkFlags) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts the
+// representation returned by String.
+func (f *LinkFlags) UnmarshalText(text []byte) error {
+	v, err := parseBits(string(text), linkFlagNames)
+	if err != nil {
+		return fmt.Errorf("invalid link flags: %v", err)
+	}
+	*f = LinkFlags(v)
+	return nil
+}
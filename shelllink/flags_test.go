@@ -0,0 +1,76 @@
+package shelllink_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gentlemanautomaton/winshell/shelllink"
+)
+
+func ExampleLinkFlags_Set() {
+	flags := shelllink.HasLinkTargetIDList | shelllink.IsUnicode
+	flags.Set(shelllink.RunAsUser)
+	fmt.Println(flags)
+	fmt.Println(flags.Has(shelllink.RunAsUser))
+
+	flags.Clear(shelllink.RunAsUser)
+	fmt.Println(flags)
+
+	// Output:
+	// HasLinkTargetIDList|IsUnicode|RunAsUser
+	// true
+	// HasLinkTargetIDList|IsUnicode
+}
+
+func TestLinkFlagsText(t *testing.T) {
+	tests := []struct {
+		Flags shelllink.LinkFlags
+		Text  string
+	}{
+		{0, "0"},
+		{shelllink.HasLinkInfo | shelllink.PreferEnvironmentPath, "HasLinkInfo|PreferEnvironmentPath"},
+		{shelllink.ForceNoLinkTrack | 0x800, "ForceNoLinkTrack|0x800"},
+	}
+
+	for _, test := range tests {
+		text, err := test.Flags.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(text) != test.Text {
+			t.Errorf("%#x: got %q, want %q", uint32(test.Flags), text, test.Text)
+		}
+
+		var flags shelllink.LinkFlags
+		if err := flags.UnmarshalText(text); err != nil {
+			t.Fatal(err)
+		}
+		if flags != test.Flags {
+			t.Errorf("%s: got %#x, want %#x", test.Text, uint32(flags), uint32(test.Flags))
+		}
+	}
+
+	var flags shelllink.LinkFlags
+	if err := flags.UnmarshalText([]byte("IsUnicode|Bogus")); err == nil {
+		t.Errorf("unmarshaling an unrecognized flag did not fail")
+	}
+}
+
+func TestFileAttributesText(t *testing.T) {
+	attrs := shelllink.FileAttributeReadOnly | shelllink.FileAttributeArchive
+	if got, want := attrs.String(), "FileAttributeReadOnly|FileAttributeArchive"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	var parsed shelllink.FileAttributes
+	if err := parsed.UnmarshalText([]byte("fileattributehidden | 0x20")); err != nil {
+		t.Fatal(err)
+	}
+	if want := shelllink.FileAttributeHidden | shelllink.FileAttributeArchive; parsed != want {
+		t.Errorf("got %s, want %s", parsed, want)
+	}
+
+	if err := parsed.UnmarshalText([]byte("FILE_ATTRIBUTE_HIDDEN")); err == nil {
+		t.Errorf("unmarshaling a Windows constant name did not fail")
+	}
+}
@@ -0,0 +1,162 @@
+package shelllink
+
+import (
+	"encoding/binary"
+
+	"github.com/gentlemanautomaton/winshell/internal/guid"
+	"github.com/gentlemanautomaton/winshell/shellns"
+	"github.com/google/uuid"
+)
+
+// knownFolderDataSize is the number of bytes in a KnownFolderDataBlock,
+// excluding its size and signature.
+const knownFolderDataSize = 0x1C - blockHeaderSize
+
+// specialFolderDataSize is the number of bytes in a
+// SpecialFolderDataBlock, excluding its size and signature.
+const specialFolderDataSize = 0x10 - blockHeaderSize
+
+// KnownFolderData identifies the known folder that contains a link's
+// target. It corresponds to a KnownFolderDataBlock.
+//
+// Offset is the byte offset of an item within the link's target ID list,
+// as returned by shellns.List.Offset. The items that precede it identify
+// the known folder. Windows uses this block to keep links working when
+// the folder is redirected.
+type KnownFolderData struct {
+	FolderID uuid.UUID
+	Offset   uint32
+}
+
+// NewKnownFolderData returns a known folder data block for the folder
+// identified by id. The folder is represented by the first n items of
+// list.
+func NewKnownFolderData(id uuid.UUID, list shellns.List, n int) *KnownFolderData {
+	return &KnownFolderData{FolderID: id, Offset: uint32(list.Offset(n))}
+}
+
+// Signature returns the signature of the block.
+func (k KnownFolderData) Signature() Signature {
+	return KnownFolderDataBlock
+}
+
+// Index returns the index of the item within list that the block's
+// offset refers to. The items before it identify the known folder. It
+// returns false if no item in list begins at the offset.
+func (k KnownFolderData) Index(list shellns.List) (int, bool) {
+	return list.IndexAt(int(k.Offset))
+}
+
+// UnmarshalBinary parses known folder data from data, which excludes the
+// block's size and signature.
+func (k *KnownFolderData) UnmarshalBinary(data []byte) error {
+	if err := checkBlockSize(KnownFolderDataBlock, data, knownFolderDataSize); err != nil {
+		return err
+	}
+	k.FolderID = guid.Decode(data[0:16])
+	k.Offset = binary.LittleEndian.Uint32(data[16:20])
+	return nil
+}
+
+// MarshalBinary returns the binary representation of the known folder
+// data, excluding the block's size and signature.
+func (k KnownFolderData) MarshalBinary() ([]byte, error) {
+	data := make([]byte, knownFolderDataSize)
+	guid.Put(data[0:16], k.FolderID)
+	binary.LittleEndian.PutUint32(data[16:20], k.Offset)
+	return data, nil
+}
+
+// SpecialFolderData identifies the special folder that contains a link's
+// target. It corresponds to a SpecialFolderDataBlock.
+//
+// FolderID is a CSIDL value, such as 0x26 for CSIDL_PROGRAM_FILES.
+// Offset is the byte offset of an item within the link's target ID list,
+// as returned by shellns.List.Offset. The items that precede it identify
+// the special folder.
+type SpecialFolderData struct {
+	FolderID uint32
+	Offset   uint32
+}
+
+// NewSpecialFolderData returns a special folder data block for the
+// folder identified by the CSIDL value id. The folder is represented by
+// the first n items of list.
+func NewSpecialFolderData(id uint32, list shellns.List, n int) *SpecialFolderData {
+	return &SpecialFolderData{FolderID: id, Offset: uint32(list.Offset(n))}
+}
+
+// Signature returns the signature of the block.
+func (s SpecialFolderData) Signature() Signature {
+	return SpecialFolderDataBlock
+}
+
+// Index returns the index of the item within list that the block's
+// offset refers to. The items before it identify the special folder. It
+// returns false if no item in list begins at the offset.
+func (s SpecialFolderData) Index(list shellns.List) (int, bool) {
+	return list.IndexAt(int(s.Offset))
+}
+
+// UnmarshalBinary parses special folder data from data, which excludes
+// the block's size and signature.
+func (s *SpecialFolderData) UnmarshalBinary(data []byte) error {
+	if err := checkBlockSize(SpecialFolderDataBlock, data, specialFolderDataSize); err != nil {
+		return err
+	}
+	s.FolderID = binary.LittleEndian.Uint32(data[0:4])
+	s.Offset = binary.LittleEndian.Uint32(data[4:8])
+	return nil
+}
+
+// MarshalBinary returns the binary representation of the special folder
+// data, excluding the block's size and signature.
+func (s SpecialFolderData) MarshalBinary() ([]byte, error) {
+	data := make([]byte, specialFolderDataSize)
+	binary.LittleEndian.PutUint32(data[0:4], s.FolderID)
+	binary.LittleEndian.PutUint32(data[4:8], s.Offset)
+	return data, nil
+}
+
+// KnownFolder returns the known folder that contains the link's target,
+// along with the leading items of the target ID list that identify it.
+// It returns false if the link lacks a known folder data block or if the
+// block's offset does not refer to an item in the target ID list.
+func (link Link) KnownFolder() (id uuid.UUID, folder shellns.List, ok bool) {
+	var block KnownFolderData
+	switch b := link.ExtraData.Get(KnownFolderDataBlock).(type) {
+	case *KnownFolderData:
+		block = *b
+	case KnownFolderData:
+		block = b
+	default:
+		return uuid.Nil, nil, false
+	}
+	index, ok := block.Index(link.IDList)
+	if !ok {
+		return uuid.Nil, nil, false
+	}
+	return block.FolderID, link.IDList[:index:index], true
+}
+
+// SpecialFolder returns the CSIDL value of the special folder that
+// contains the link's target, along with the leading items of the target
+// ID list that identify it. It returns false if the link lacks a special
+// folder data block or if the block's offset does not refer to an item in
+// the target ID list.
+func (link Link) SpecialFolder() (id uint32, folder shellns.List, ok bool) {
+	var block SpecialFolderData
+	switch b := link.ExtraData.Get(SpecialFolderDataBlock).(type) {
+	case *SpecialFolderData:
+		block = *b
+	case SpecialFolderData:
+		block = b
+	default:
+		return 0, nil, false
+	}
+	index, ok := block.Index(link.IDList)
+	if !ok {
+		return 0, nil, false
+	}
+	return block.FolderID, link.IDList[:index:index], true
+}
@@ -0,0 +1,65 @@
+package shelllink_test
+
+import (
+	"testing"
+
+	"github.com/gentlemanautomaton/winshell/shelllink"
+	"github.com/gentlemanautomaton/winshell/shellns"
+	"github.com/google/uuid"
+)
+
+func TestLinkKnownFolder(t *testing.T) {
+	// FOLDERID_ProgramFiles
+	programFiles := uuid.MustParse("905E63B6-C1BF-494E-B29C-65B732D3D21A")
+	const csidlProgramFiles = 0x26
+
+	list, err := shellns.FromPath(`C:\Program Files\App\app.exe`, shellns.PathOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The folder is identified by the root, volume and Program Files items
+	source := shelllink.Link{
+		Header: shelllink.Header{Flags: shelllink.IsUnicode},
+		IDList: list,
+		ExtraData: shelllink.ExtraData{
+			shelllink.NewSpecialFolderData(csidlProgramFiles, list, 3),
+			shelllink.NewKnownFolderData(programFiles, list, 3),
+		},
+	}
+
+	data, err := source.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var link shelllink.Link
+	if err := link.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+
+	id, folder, ok := link.KnownFolder()
+	if !ok {
+		t.Fatal("the known folder was not found")
+	}
+	if id != programFiles {
+		t.Errorf("known folder ID: got %v, want %v", id, programFiles)
+	}
+	if got, want := folder.ParsingName(), `C:\Program Files`; got != want {
+		t.Errorf("known folder: got %q, want %q", got, want)
+	}
+
+	csidl, folder, ok := link.SpecialFolder()
+	if !ok {
+		t.Fatal("the special folder was not found")
+	}
+	if csidl != csidlProgramFiles || len(folder) != 3 {
+		t.Errorf("special folder: got %#x with %d items", csidl, len(folder))
+	}
+
+	block := link.ExtraData[1].(*shelllink.KnownFolderData)
+	block.Offset++
+	if _, _, ok := link.KnownFolder(); ok {
+		t.Error("an offset that does not refer to an item was accepted")
+	}
+}
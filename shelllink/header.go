@@ -0,0 +1,75 @@
+package shelllink
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/gentlemanautomaton/winshell/internal/filetime"
+	"github.com/gentlemanautomaton/winshell/internal/guid"
+	"github.com/gentlemanautomaton/winshell/shellclass"
+)
+
+// HeaderSize is the number of bytes in a shell link header.
+const HeaderSize = 0x4C
+
+// Header holds identification information, timestamps and flags for a
+// shell link.
+//
+// https://docs.microsoft.com/en-us/openspecs/windows_protocols/ms-shllink/c3376b21-0931-45e4-b2fc-a48ac0e60d15
+type Header struct {
+	Flags          LinkFlags
+	FileAttributes FileAttributes
+	CreationTime   time.Time
+	AccessTime     time.Time
+	WriteTime      time.Time
+	FileSize       uint32
+	IconIndex      int32
+	ShowCommand    ShowCommand
+	Hotkey         Hotkey
+}
+
+// UnmarshalBinary parses a shell link header from data.
+func (h *Header) UnmarshalBinary(data []byte) error {
+	if len(data) < HeaderSize {
+		return fmt.Errorf("the shell link header requires %d bytes, but only %d bytes are present", HeaderSize, len(data))
+	}
+
+	if size := binary.LittleEndian.Uint32(data[0:4]); size != HeaderSize {
+		return fmt.Errorf("the shell link header declares a size of %d bytes instead of %d", size, HeaderSize)
+	}
+
+	if clsid := guid.Decode(data[4:20]); clsid != shellclass.ShellLink {
+		return fmt.Errorf("the shell link header contains an unexpected class identifier: %s", clsid)
+	}
+
+	h.Flags = LinkFlags(binary.LittleEndian.Uint32(data[20:24]))
+	h.FileAttributes = FileAttributes(binary.LittleEndian.Uint32(data[24:28]))
+	h.CreationTime = filetime.ToTime(binary.LittleEndian.Uint64(data[28:36]))
+	h.AccessTime = filetime.ToTime(binary.LittleEndian.Uint64(data[36:44]))
+	h.WriteTime = filetime.ToTime(binary.LittleEndian.Uint64(data[44:52]))
+	h.FileSize = binary.LittleEndian.Uint32(data[52:56])
+	h.IconIndex = int32(binary.LittleEndian.Uint32(data[56:60]))
+	h.ShowCommand = ShowCommand(binary.LittleEndian.Uint32(data[60:64]))
+	h.Hotkey = Hotkey(binary.LittleEndian.Uint16(data[64:66]))
+
+	return nil
+}
+
+// MarshalBinary returns the binary representation of the shell link
+// header.
+func (h Header) MarshalBinary() ([]byte, error) {
+	data := make([]byte, HeaderSize)
+	binary.LittleEndian.PutUint32(data[0:4], HeaderSize)
+	guid.Put(data[4:20], shellclass.ShellLink)
+	binary.LittleEndian.PutUint32(data[20:24], uint32(h.Flags))
+	binary.LittleEndian.PutUint32(data[24:28], uint32(h.FileAttributes))
+	binary.LittleEndian.PutUint64(data[28:36], filetime.FromTime(h.CreationTime))
+	binary.LittleEndian.PutUint64(data[36:44], filetime.FromTime(h.AccessTime))
+	binary.LittleEndian.PutUint64(data[44:52], filetime.FromTime(h.WriteTime))
+	binary.LittleEndian.PutUint32(data[52:56], h.FileSize)
+	binary.LittleEndian.PutUint32(data[56:60], uint32(h.IconIndex))
+	binary.LittleEndian.PutUint32(data[60:64], uint32(h.ShowCommand))
+	binary.LittleEndian.PutUint16(data[64:66], uint16(h.Hotkey))
+	return data, nil
+}
@@ -0,0 +1,262 @@
+package shelllink
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Hotkey is a keyboard shortcut that activates a shell link. The low byte
+// holds a virtual key code and the high byte holds modifier flags.
+//
+// The zero value indicates that the link has no hotkey.
+//
+// https://docs.microsoft.com/en-us/openspecs/windows_protocols/ms-shllink/8cd21240-1b5a-4f0c-a2bb-5ae3c7f6e0c5
+type Hotkey uint16
+
+// HotkeyModifiers are the modifier keys of a hotkey.
+type HotkeyModifiers uint8
+
+// Hotkey modifiers.
+const (
+	HotkeyShift   HotkeyModifiers = 0x01 // HOTKEYF_SHIFT
+	HotkeyControl HotkeyModifiers = 0x02 // HOTKEYF_CONTROL
+	HotkeyAlt     HotkeyModifiers = 0x04 // HOTKEYF_ALT
+	HotkeyExt     HotkeyModifiers = 0x08 // HOTKEYF_EXT
+)
+
+// Virtual key codes that are given names by hotkeys. Keys for the digits
+// 0 through 9 and the letters A through Z share the values of their ASCII
+// characters.
+const (
+	keyF1         = 0x70
+	keyF24        = 0x87
+	keyNumLock    = 0x90
+	keyScrollLock = 0x91
+)
+
+// Hotkey validation errors.
+var (
+	ErrHotkeyKey       = errors.New("the hotkey does not specify a supported key")
+	ErrHotkeyModifiers = errors.New("the hotkey specifies unsupported modifiers")
+	ErrHotkeyCombo     = errors.New("the hotkey must include Ctrl or Alt unless its key is a function key")
+)
+
+// NewHotkey returns a hotkey for the given virtual key code and
+// modifiers.
+func NewHotkey(key uint8, modifiers HotkeyModifiers) Hotkey {
+	return Hotkey(uint16(modifiers)<<8 | uint16(key))
+}
+
+// ParseHotkey parses a hotkey from a string such as "Ctrl+Shift+K" or
+// "Ctrl+Alt+F5". Modifiers and key names are matched without regard to
+// case and may be surrounded by spaces. The string "None" or an empty
+// string returns a zero hotkey.
+//
+// Modifiers and keys without names may be given in hexadecimal, such as
+// "Ctrl+0x10+0x20", as written by Hotkey.String. A hotkey that includes
+// a hexadecimal element is returned as-is, so that any hotkey read from
+// a link can be parsed. All other hotkeys are validated.
+func ParseHotkey(s string) (Hotkey, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || strings.EqualFold(s, "None") {
+		return 0, nil
+	}
+
+	parts := strings.Split(s, "+")
+	var (
+		modifiers HotkeyModifiers
+		raw       bool
+	)
+	for _, part := range parts[:len(parts)-1] {
+		part = strings.TrimSpace(part)
+		if value, ok := parseHotkeyHex(part); ok {
+			modifiers |= HotkeyModifiers(value)
+			raw = true
+			continue
+		}
+		switch strings.ToLower(part) {
+		case "ctrl", "control":
+			modifiers |= HotkeyControl
+		case "alt":
+			modifiers |= HotkeyAlt
+		case "shift":
+			modifiers |= HotkeyShift
+		case "ext":
+			modifiers |= HotkeyExt
+		default:
+			return 0, fmt.Errorf("invalid hotkey \"%s\": unrecognized modifier \"%s\"", s, part)
+		}
+	}
+
+	name := strings.TrimSpace(parts[len(parts)-1])
+	if name == "" {
+		return 0, fmt.Errorf("invalid hotkey \"%s\": missing key", s)
+	}
+	key, ok := parseHotkeyHex(name)
+	if ok {
+		raw = true
+	} else if key, ok = parseHotkeyKey(name); !ok {
+		return 0, fmt.Errorf("invalid hotkey \"%s\": unrecognized key \"%s\"", s, name)
+	}
+
+	hotkey := NewHotkey(key, modifiers)
+	if raw {
+		return hotkey, nil
+	}
+	if err := hotkey.Validate(); err != nil {
+		return 0, fmt.Errorf("invalid hotkey \"%s\": %w", s, err)
+	}
+	return hotkey, nil
+}
+
+// Key returns the virtual key code of the hotkey.
+func (h Hotkey) Key() uint8 {
+	return uint8(h)
+}
+
+// Modifiers returns the modifier keys of the hotkey.
+func (h Hotkey) Modifiers() HotkeyModifiers {
+	return HotkeyModifiers(h >> 8)
+}
+
+// Validate returns an error if the hotkey is not one that Windows
+// accepts for a shell link. A zero hotkey is valid.
+//
+// Windows requires the key to be a digit, a letter, a function key,
+// Num Lock or Scroll Lock. Keys other than function keys must be
+// combined with Ctrl or Alt. The Ext modifier, which the hotkey control
+// reports for extended keys, is permitted.
+func (h Hotkey) Validate() error {
+	if h == 0 {
+		return nil
+	}
+
+	modifiers := h.Modifiers()
+	if modifiers&^(HotkeyShift|HotkeyControl|HotkeyAlt|HotkeyExt) != 0 {
+		return ErrHotkeyModifiers
+	}
+
+	key := h.Key()
+	switch {
+	case key >= keyF1 && key <= keyF24:
+		return nil
+	case isHotkeyChar(key), key == keyNumLock, key == keyScrollLock:
+		if modifiers&(HotkeyControl|HotkeyAlt) == 0 {
+			return ErrHotkeyCombo
+		}
+		return nil
+	default:
+		return ErrHotkeyKey
+	}
+}
+
+// String returns a string representation of the hotkey, such as
+// "Ctrl+Alt+F5". A zero hotkey is returned as "None". Modifiers and keys
+// without names are written in hexadecimal, in a form that ParseHotkey
+// accepts.
+func (h Hotkey) String() string {
+	if h == 0 {
+		return "None"
+	}
+
+	var parts []string
+	modifiers := h.Modifiers()
+	if modifiers&HotkeyControl != 0 {
+		parts = append(parts, "Ctrl")
+	}
+	if modifiers&HotkeyShift != 0 {
+		parts = append(parts, "Shift")
+	}
+	if modifiers&HotkeyAlt != 0 {
+		parts = append(parts, "Alt")
+	}
+	if modifiers&HotkeyExt != 0 {
+		parts = append(parts, "Ext")
+	}
+	if extra := modifiers &^ (HotkeyShift | HotkeyControl | HotkeyAlt | HotkeyExt); extra != 0 {
+		parts = append(parts, fmt.Sprintf("0x%02x", uint8(extra)))
+	}
+
+	return strings.Join(append(parts, hotkeyKeyName(h.Key())), "+")
+}
+
+// MarshalText implements encoding.TextMarshaler. It returns the same
+// representation as String.
+func (h Hotkey) MarshalText() ([]byte, error) {
+	return []byte(h.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It parses text
+// with ParseHotkey.
+func (h *Hotkey) UnmarshalText(text []byte) error {
+	hotkey, err := ParseHotkey(string(text))
+	if err != nil {
+		return err
+	}
+	*h = hotkey
+	return nil
+}
+
+// isHotkeyChar returns true if key is the virtual key code of a digit or
+// a letter.
+func isHotkeyChar(key uint8) bool {
+	return (key >= '0' && key <= '9') || (key >= 'A' && key <= 'Z')
+}
+
+// hotkeyKeyName returns the name of a virtual key code.
+func hotkeyKeyName(key uint8) string {
+	switch {
+	case isHotkeyChar(key):
+		return string(rune(key))
+	case key >= keyF1 && key <= keyF24:
+		return "F" + strconv.Itoa(int(key-keyF1)+1)
+	case key == keyNumLock:
+		return "NumLock"
+	case key == keyScrollLock:
+		return "ScrollLock"
+	default:
+		return fmt.Sprintf("0x%02x", key)
+	}
+}
+
+// parseHotkeyKey returns the virtual key code for the given key name.
+func parseHotkeyKey(name string) (uint8, bool) {
+	switch len(name) {
+	case 0:
+		return 0, false
+	case 1:
+		key := strings.ToUpper(name)[0]
+		return key, isHotkeyChar(key)
+	}
+
+	switch strings.ToLower(name) {
+	case "numlock":
+		return keyNumLock, true
+	case "scrolllock", "scroll":
+		return keyScrollLock, true
+	}
+
+	if name[0] == 'F' || name[0] == 'f' {
+		n, err := strconv.Atoi(name[1:])
+		if err == nil && n >= 1 && n <= keyF24-keyF1+1 {
+			return uint8(keyF1 + n - 1), true
+		}
+	}
+
+	return 0, false
+}
+
+// parseHotkeyHex parses a modifier or key written in hexadecimal with a
+// 0x prefix.
+func parseHotkeyHex(s string) (uint8, bool) {
+	if len(s) < 3 || s[0] != '0' || (s[1] != 'x' && s[1] != 'X') {
+		return 0, false
+	}
+	value, err := strconv.ParseUint(s[2:], 16, 8)
+	if err != nil {
+		return 0, false
+	}
+	return uint8(value), true
+}
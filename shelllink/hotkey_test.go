@@ -0,0 +1,104 @@
+package shelllink_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gentlemanautomaton/winshell/shelllink"
+)
+
+func ExampleParseHotkey() {
+	hotkey, err := shelllink.ParseHotkey("ctrl + shift + k")
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("%s %#04x\n", hotkey, uint16(hotkey))
+
+	// Output: Ctrl+Shift+K 0x034b
+}
+
+func TestParseHotkey(t *testing.T) {
+	tests := []struct {
+		Text   string
+		Hotkey shelllink.Hotkey
+		Err    error
+	}{
+		{"", 0, nil},
+		{"None", 0, nil},
+		{"Ctrl+Alt+F5", shelllink.NewHotkey(0x74, shelllink.HotkeyControl|shelllink.HotkeyAlt), nil},
+		{"F12", shelllink.NewHotkey(0x7B, 0), nil},
+		{"Shift+F24", shelllink.NewHotkey(0x87, shelllink.HotkeyShift), nil},
+		{"Alt+7", shelllink.NewHotkey('7', shelllink.HotkeyAlt), nil},
+		{"Control+ScrollLock", shelllink.NewHotkey(0x91, shelllink.HotkeyControl), nil},
+		{"Ctrl+Ext+A", shelllink.NewHotkey('A', shelllink.HotkeyControl|shelllink.HotkeyExt), nil},
+		{"0x08+A", shelllink.NewHotkey('A', shelllink.HotkeyExt), nil},
+		{"Ctrl+0x10+A", shelllink.NewHotkey('A', shelllink.HotkeyControl|0x10), nil},
+		{"Alt+0x2e", shelllink.NewHotkey(0x2E, shelllink.HotkeyAlt), nil},
+		{"K", 0, shelllink.ErrHotkeyCombo},
+		{"Shift+K", 0, shelllink.ErrHotkeyCombo},
+		{"Ctrl+F25", 0, nil},
+		{"Win+K", 0, nil},
+		{"Ctrl+", 0, nil},
+		{"Ctrl+Alt+", 0, nil},
+		{"Ctrl+0x100", 0, nil},
+		{"0x+A", 0, nil},
+	}
+
+	for _, test := range tests {
+		hotkey, err := shelllink.ParseHotkey(test.Text)
+		switch {
+		case test.Err != nil:
+			if !errors.Is(err, test.Err) {
+				t.Errorf("%q: got error %v, want %v", test.Text, err, test.Err)
+			}
+		case test.Hotkey == 0 && test.Text != "" && test.Text != "None":
+			if err == nil {
+				t.Errorf("%q: parsing did not fail", test.Text)
+			}
+		case err != nil:
+			t.Errorf("%q: %v", test.Text, err)
+		case hotkey != test.Hotkey:
+			t.Errorf("%q: got %#04x, want %#04x", test.Text, uint16(hotkey), uint16(test.Hotkey))
+		}
+	}
+}
+
+func TestHotkeyUnmarshalTextInvalid(t *testing.T) {
+	for _, text := range []string{"Ctrl+", "Ctrl+Alt+", "+"} {
+		var hotkey shelllink.Hotkey
+		if err := hotkey.UnmarshalText([]byte(text)); err == nil {
+			t.Errorf("%q: unmarshaling did not fail", text)
+		}
+	}
+}
+
+func TestHotkeyString(t *testing.T) {
+	for _, text := range []string{"None", "Ctrl+Shift+Alt+Z", "Alt+NumLock", "F1", "Ctrl+Ext+A", "Ctrl+0x10+0x2e"} {
+		hotkey, err := shelllink.ParseHotkey(text)
+		if err != nil {
+			t.Fatalf("%q: %v", text, err)
+		}
+		if got := hotkey.String(); got != text {
+			t.Errorf("got %q, want %q", got, text)
+		}
+	}
+}
+
+func TestHotkeyStringRoundTrip(t *testing.T) {
+	// Include Ctrl so that hotkeys with named keys pass validation
+	for bit := 0; bit < 8; bit++ {
+		for _, key := range []uint8{'A', 0x74, 0x2E} {
+			hotkey := shelllink.NewHotkey(key, shelllink.HotkeyControl|shelllink.HotkeyModifiers(1<<bit))
+			text := hotkey.String()
+			parsed, err := shelllink.ParseHotkey(text)
+			if err != nil {
+				t.Errorf("%#04x: %q: %v", uint16(hotkey), text, err)
+				continue
+			}
+			if parsed != hotkey {
+				t.Errorf("%#04x: %q parsed as %#04x", uint16(hotkey), text, uint16(parsed))
+			}
+		}
+	}
+}
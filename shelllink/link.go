@@ -0,0 +1,142 @@
+package shelllink
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/gentlemanautomaton/winshell/shellns"
+)
+
+// Link is a shell link. It holds the decoded contents of a shell link
+// (.lnk) file.
+//
+// https://docs.microsoft.com/en-us/openspecs/windows_protocols/ms-shllink/16cb4ca1-9339-4d0c-a68d-bf1d6cc0f943
+type Link struct {
+	Header     Header
+	IDList     shellns.List
+	LinkInfo   *LinkInfo
+	StringData StringData
+	ExtraData  ExtraData
+
+	// CodePage is the ANSI code page used for strings that are not
+	// stored as Unicode. It is not stored in the link itself, so it must
+	// be set before decoding links created on systems with a different
+	// ANSI code page, such as 932 for Japanese. It is retained by
+	// UnmarshalBinary. The zero value selects Windows-1252.
+	CodePage CodePage
+}
+
+// UnmarshalBinary parses the binary representation of a shell link.
+// Strings that are not stored as Unicode are decoded with the link's
+// code page.
+func (link *Link) UnmarshalBinary(data []byte) error {
+	cp := link.CodePage
+	*link = Link{CodePage: cp}
+
+	if err := link.Header.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	flags := link.Header.Flags
+	offset := HeaderSize
+
+	if flags&HasLinkTargetIDList != 0 {
+		if len(data)-offset < 2 {
+			return fmt.Errorf("the shell link is truncated before its link target ID list")
+		}
+		size := 2 + int(binary.LittleEndian.Uint16(data[offset:offset+2]))
+		if len(data)-offset < size {
+			return fmt.Errorf("the shell link target ID list declares a size of %d bytes, but only %d bytes remain", size-2, len(data)-offset-2)
+		}
+		if err := link.IDList.UnmarshalBinary(data[offset : offset+size]); err != nil {
+			return fmt.Errorf("failed to parse link target ID list: %v", err)
+		}
+		offset += size
+	}
+
+	if flags&HasLinkInfo != 0 {
+		if len(data)-offset < 4 {
+			return fmt.Errorf("the shell link is truncated before its link info structure")
+		}
+		size := int(binary.LittleEndian.Uint32(data[offset : offset+4]))
+		if len(data)-offset < size {
+			return fmt.Errorf("the shell link info structure declares a size of %d bytes, but only %d bytes remain", size, len(data)-offset)
+		}
+		link.LinkInfo = new(LinkInfo)
+		if err := link.LinkInfo.unmarshal(data[offset:offset+size], cp); err != nil {
+			return fmt.Errorf("failed to parse link info: %v", err)
+		}
+		offset += size
+	}
+
+	n, err := link.StringData.unmarshal(data[offset:], flags, cp)
+	if err != nil {
+		return err
+	}
+	offset += n
+
+	if _, err := link.ExtraData.unmarshal(data[offset:], cp); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// MarshalBinary returns the binary representation of the shell link.
+//
+// The flags that indicate the presence of the ID list, link info and
+// string data are derived from the content of the link. Flags that
+// indicate the presence of extra data blocks, such as HasExpString, are
+// set when the blocks are present and cleared when they are not.
+//
+// The strings are encoded as UTF-16 if the IsUnicode flag is set in the
+// header, otherwise they are encoded with the link's code page.
+func (link Link) MarshalBinary() ([]byte, error) {
+	const structural = HasLinkTargetIDList | HasLinkInfo | HasName | HasRelativePath | HasWorkingDir | HasArguments | HasIconLocation
+	const blocks = HasExpString | HasDarwinID | HasExpIcon | RunWithShimLayer
+
+	header := link.Header
+	header.Flags &^= structural | blocks
+	if link.IDList != nil {
+		header.Flags |= HasLinkTargetIDList
+	}
+	if link.LinkInfo != nil {
+		header.Flags |= HasLinkInfo
+	}
+	header.Flags |= link.StringData.flags()
+	header.Flags |= link.ExtraData.flags()
+
+	data, err := header.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	if link.IDList != nil {
+		list, err := link.IDList.MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal link target ID list: %v", err)
+		}
+		data = append(data, list...)
+	}
+
+	if link.LinkInfo != nil {
+		info, err := link.LinkInfo.marshal(link.CodePage)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal link info: %v", err)
+		}
+		data = append(data, info...)
+	}
+
+	strings, err := link.StringData.marshal(header.Flags&IsUnicode != 0, link.CodePage)
+	if err != nil {
+		return nil, err
+	}
+	data = append(data, strings...)
+
+	extra, err := link.ExtraData.marshal(link.CodePage)
+	if err != nil {
+		return nil, err
+	}
+	data = append(data, extra...)
+
+	return data, nil
+}
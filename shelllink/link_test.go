@@ -0,0 +1,236 @@
+package shelllink_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/gentlemanautomaton/winshell/shelllink"
+	"github.com/gentlemanautomaton/winshell/shellns"
+)
+
+// sampleLink returns a hand-assembled shell link that points to
+// C:\test\a.txt.
+func sampleLink() []byte {
+	var buf bytes.Buffer
+	le := func(v interface{}) { binary.Write(&buf, binary.LittleEndian, v) }
+	utf := func(s string) {
+		units := utf16.Encode([]rune(s))
+		le(uint16(len(units)))
+		le(units)
+	}
+
+	// Header
+	le(uint32(0x4C))
+	buf.Write([]byte{0x01, 0x14, 0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0xC0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x46})
+	le(uint32(shelllink.HasLinkTargetIDList | shelllink.HasLinkInfo | shelllink.HasRelativePath | shelllink.HasWorkingDir | shelllink.IsUnicode))
+	le(uint32(shelllink.FileAttributeArchive))
+	le(uint64(0x01CD9A7A8A6C8F00)) // Creation
+	le(uint64(0x01CD9A7A8A6C8F00)) // Access
+	le(uint64(0x01CD9A7A8A6C8F00)) // Write
+	le(uint32(0))                  // File size
+	le(int32(0))                   // Icon index
+	le(uint32(shelllink.ShowNormal))
+	le(uint16(0))               // Hotkey
+	buf.Write(make([]byte, 10)) // Reserved
+
+	// LinkTargetIDList
+	root := []byte{0x1F, 0x50, 0xE0, 0x4F, 0xD0, 0x20, 0xEA, 0x3A, 0x69, 0x10, 0xA2, 0xD8, 0x08, 0x00, 0x2B, 0x30, 0x30, 0x9D}
+	le(uint16(2 + len(root) + 2))
+	le(uint16(2 + len(root)))
+	buf.Write(root)
+	le(uint16(0))
+
+	// LinkInfo
+	le(uint32(0x3C))       // LinkInfoSize
+	le(uint32(0x1C))       // LinkInfoHeaderSize
+	le(uint32(0x01))       // LinkInfoFlags
+	le(uint32(0x1C))       // VolumeIDOffset
+	le(uint32(0x2D))       // LocalBasePathOffset
+	le(uint32(0x00))       // CommonNetworkRelativeLinkOffset
+	le(uint32(0x3B))       // CommonPathSuffixOffset
+	le(uint32(0x11))       // VolumeIDSize
+	le(uint32(3))          // DriveType
+	le(uint32(0x307A8A81)) // DriveSerialNumber
+	le(uint32(0x10))       // VolumeLabelOffset
+	buf.WriteByte(0)       // VolumeLabel
+	buf.WriteString("C:\\test\\a.txt\x00")
+	buf.WriteByte(0) // CommonPathSuffix
+
+	// StringData
+	utf(`.\a.txt`)
+	utf(`C:\test`)
+
+	// ExtraData
+	le(uint32(0x10))
+	le(uint32(shelllink.SpecialFolderDataBlock))
+	le(uint32(0x25))
+	le(uint32(0x14))
+	le(uint32(0))
+
+	return buf.Bytes()
+}
+
+func TestLinkUnmarshalBinary(t *testing.T) {
+	var link shelllink.Link
+	if err := link.UnmarshalBinary(sampleLink()); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := link.Header.FileAttributes; got != shelllink.FileAttributeArchive {
+		t.Errorf("file attributes: got %#x, want %#x", got, shelllink.FileAttributeArchive)
+	}
+	if got, want := link.Header.WriteTime.Format("2006-01-02"), "2012-09-24"; got != want {
+		t.Errorf("write time: got %s, want %s", got, want)
+	}
+	if got := len(link.IDList); got != 1 {
+		t.Errorf("ID list: got %d items, want 1", got)
+	}
+	if link.LinkInfo == nil || link.LinkInfo.VolumeID == nil {
+		t.Fatal("link info or volume ID is missing")
+	}
+	if got, want := link.LinkInfo.LocalBasePath, `C:\test\a.txt`; got != want {
+		t.Errorf("local base path: got %q, want %q", got, want)
+	}
+	if got, want := link.LinkInfo.VolumeID.SerialNumber, uint32(0x307A8A81); got != want {
+		t.Errorf("volume serial number: got %#x, want %#x", got, want)
+	}
+	if got, want := link.StringData.RelativePath, `.\a.txt`; got != want {
+		t.Errorf("relative path: got %q, want %q", got, want)
+	}
+	if got, want := link.StringData.WorkingDir, `C:\test`; got != want {
+		t.Errorf("working directory: got %q, want %q", got, want)
+	}
+	if got := len(link.ExtraData); got != 1 {
+		t.Fatalf("extra data: got %d blocks, want 1", got)
+	}
+	if got := link.ExtraData[0].Signature(); got != shelllink.SpecialFolderDataBlock {
+		t.Errorf("extra data signature: got %#x, want %#x", got, shelllink.SpecialFolderDataBlock)
+	}
+}
+
+func TestLinkUnmarshalBinaryTruncated(t *testing.T) {
+	data := sampleLink()
+	for _, n := range []int{0, 0x20, 0x4C, 0x60, 0x80} {
+		var link shelllink.Link
+		if err := link.UnmarshalBinary(data[:n]); err == nil {
+			t.Errorf("truncated at %d bytes: expected an error", n)
+		}
+	}
+}
+
+func TestLinkRoundTrip(t *testing.T) {
+	var link shelllink.Link
+	if err := link.UnmarshalBinary(sampleLink()); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := link.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(data, sampleLink()) {
+		t.Errorf("marshaled link differs from the original:\n got %x\nwant %x", data, sampleLink())
+	}
+}
+
+func ExamplePath_Link() {
+	link, err := shelllink.Path(`C:\Users`).Link()
+	if err != nil {
+		panic(err)
+	}
+
+	data, err := link.MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("%x", data[:24])
+
+	// Output: 4c0000000114020000000000c00000000000004683000000
+}
+
+func TestPathLink(t *testing.T) {
+	for _, path := range []string{`C:\Users`, `C:\Program Files\App\app.exe`, `\\server\share\dir\file.txt`} {
+		source, err := shelllink.Path(path).Link()
+		if err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+		data, err := source.MarshalBinary()
+		if err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+
+		var link shelllink.Link
+		if err := link.UnmarshalBinary(data); err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+
+		if link.LinkInfo == nil {
+			t.Fatalf("%s: link info is missing", path)
+		}
+		if link.LinkInfo.VolumeID != nil {
+			if got := link.LinkInfo.LocalBasePath; got != path {
+				t.Errorf("%s: local base path: got %q", path, got)
+			}
+		} else if got := link.LinkInfo.NetworkLink.NetName + `\` + link.LinkInfo.CommonPathSuffix; !strings.EqualFold(got, path) {
+			t.Errorf("%s: network path: got %q", path, got)
+		}
+	}
+}
+
+func TestPathLinkForms(t *testing.T) {
+	tests := []struct {
+		Path string
+		Want string
+	}{
+		{`C:/Users/alice`, `C:\Users\alice`},
+		{`C:\a\..\b`, `C:\b`},
+		{`C:\a\..\b\.\c.exe`, `C:\b\c.exe`},
+		{`C:\Users\\alice\`, `C:\Users\alice`},
+	}
+
+	for _, test := range tests {
+		link, err := shelllink.Path(test.Path).Link()
+		if err != nil {
+			t.Errorf("%q: %v", test.Path, err)
+			continue
+		}
+		if got := link.LinkInfo.LocalBasePath; got != test.Want {
+			t.Errorf("%q: local base path: got %q, want %q", test.Path, got, test.Want)
+		}
+		if got := link.IDList.ParsingName(); got != test.Want {
+			t.Errorf("%q: ID list: got %q, want %q", test.Path, got, test.Want)
+		}
+	}
+
+	dir, err := shelllink.Path(`C:\Users\.\alice\`).Link()
+	if err != nil {
+		t.Fatal(err)
+	}
+	last, err := dir.IDList[len(dir.IDList)-1].Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry, ok := last.(*shellns.FileEntryItem); !ok || !entry.IsDir() {
+		t.Errorf("trailing separator: the final item is not a directory: %#v", last)
+	}
+
+	unc, err := shelllink.Path(`\\server\share\a\..\b\.\c.txt`).Link()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := unc.LinkInfo.CommonPathSuffix; got != `b\c.txt` {
+		t.Errorf("UNC common path suffix: got %q", got)
+	}
+
+	for _, path := range []string{``, `Users`, `\Users`, `C:Users`, `\\server`, `\\server\`, `\\.\COM1`, `\\?\C:\Users`} {
+		if _, err := shelllink.Path(path).Link(); err == nil {
+			t.Errorf("%q: expected an error", path)
+		}
+	}
+}
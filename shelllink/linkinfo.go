@@ -0,0 +1,506 @@
+package shelllink
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/gentlemanautomaton/winshell/internal/utf16le"
+)
+
+// LinkInfo flags.
+const (
+	volumeIDAndLocalBasePath               = 0x00000001
+	commonNetworkRelativeLinkAndPathSuffix = 0x00000002
+)
+
+// CommonNetworkRelativeLink flags.
+const (
+	validDevice  = 0x00000001
+	validNetType = 0x00000002
+)
+
+// Header sizes of the structures within LinkInfo, with and without their
+// optional Unicode offsets.
+const (
+	linkInfoHeaderSize           = 0x1C
+	linkInfoUnicodeHeaderSize    = 0x24
+	volumeIDHeaderSize           = 0x10
+	volumeIDUnicodeHeaderSize    = 0x14
+	networkLinkHeaderSize        = 0x14
+	networkLinkUnicodeHeaderSize = 0x1C
+)
+
+// LinkInfo holds information necessary to resolve a link target if it
+// is not found in its original location.
+//
+// Each path is stored in the ANSI code page and may also be stored as
+// Unicode. When both forms are present, the Unicode form is preferred.
+// If either of the Unicode paths is non-empty, both Unicode paths are
+// written.
+//
+// https://docs.microsoft.com/en-us/openspecs/windows_protocols/ms-shllink/6813269d-0cc8-4be2-933f-e96e8e3412dc
+type LinkInfo struct {
+	// VolumeID describes the volume that the target was on when the link
+	// was created. It is present only for local targets.
+	VolumeID *VolumeID
+
+	// LocalBasePath is combined with CommonPathSuffix to construct the
+	// full path to a local target. It is present only with VolumeID.
+	LocalBasePath        string
+	LocalBasePathUnicode string
+
+	// NetworkLink describes the network location of the target. It is
+	// present only for targets on network shares.
+	NetworkLink *NetworkLink
+
+	// CommonPathSuffix is appended to LocalBasePath or the network name
+	// to construct the full path to the target.
+	CommonPathSuffix        string
+	CommonPathSuffixUnicode string
+}
+
+// Path returns the full path to the link target, preferring the Unicode
+// form of each element when it is present. It returns an empty string if
+// the link info describes neither a local nor a network target.
+func (info LinkInfo) Path() string {
+	suffix := prefer(info.CommonPathSuffixUnicode, info.CommonPathSuffix)
+
+	var base string
+	switch {
+	case info.VolumeID != nil:
+		base = prefer(info.LocalBasePathUnicode, info.LocalBasePath)
+	case info.NetworkLink != nil:
+		base = prefer(info.NetworkLink.NetNameUnicode, info.NetworkLink.NetName)
+	default:
+		return ""
+	}
+
+	if suffix == "" {
+		return base
+	}
+	if base == "" || strings.HasSuffix(base, `\`) {
+		return base + suffix
+	}
+	return base + `\` + suffix
+}
+
+// VolumeID describes the volume that a link target was on when the link
+// was created.
+//
+// The label is stored either in the ANSI code page or as Unicode. When
+// LabelUnicode is non-empty, it is written instead of Label.
+//
+// https://docs.microsoft.com/en-us/openspecs/windows_protocols/ms-shllink/b7b3eea7-dbff-4275-bd58-83ba3f12d87a
+type VolumeID struct {
+	DriveType    DriveType
+	SerialNumber uint32
+	Label        string
+	LabelUnicode string
+}
+
+// NetworkLink describes the network location of a link target. It
+// corresponds to the CommonNetworkRelativeLink structure.
+//
+// The net name is a UNC path, such as \\server\share. The device name is
+// a drive letter mapped to the share, such as Z:.
+//
+// https://docs.microsoft.com/en-us/openspecs/windows_protocols/ms-shllink/23bb5877-e3dd-4799-9f50-79f05f938537
+type NetworkLink struct {
+	NetName           string
+	NetNameUnicode    string
+	DeviceName        string
+	DeviceNameUnicode string
+	ProviderType      NetworkProviderType
+}
+
+// UnmarshalBinary parses a LinkInfo structure from data. Strings that are
+// not stored as Unicode are decoded as Windows-1252.
+func (info *LinkInfo) UnmarshalBinary(data []byte) error {
+	return info.unmarshal(data, CodePageDefault)
+}
+
+// unmarshal parses a LinkInfo structure from data, decoding strings that
+// are not stored as Unicode with the given code page.
+func (info *LinkInfo) unmarshal(data []byte, cp CodePage) error {
+	if len(data) < linkInfoHeaderSize {
+		return fmt.Errorf("the link info structure requires at least %d bytes, but only %d bytes are present", linkInfoHeaderSize, len(data))
+	}
+
+	size := binary.LittleEndian.Uint32(data[0:4])
+	if int(size) > len(data) || size < linkInfoHeaderSize {
+		return fmt.Errorf("the link info structure declares an invalid size of %d bytes", size)
+	}
+	data = data[:size]
+
+	headerSize := binary.LittleEndian.Uint32(data[4:8])
+	if headerSize < linkInfoHeaderSize || headerSize > size {
+		return fmt.Errorf("the link info structure declares an invalid header size of %d bytes", headerSize)
+	}
+	unicode := headerSize >= linkInfoUnicodeHeaderSize
+
+	var (
+		flags          = binary.LittleEndian.Uint32(data[8:12])
+		volumeOffset   = binary.LittleEndian.Uint32(data[12:16])
+		basePathOffset = binary.LittleEndian.Uint32(data[16:20])
+		networkOffset  = binary.LittleEndian.Uint32(data[20:24])
+		suffixOffset   = binary.LittleEndian.Uint32(data[24:28])
+	)
+
+	*info = LinkInfo{}
+
+	if flags&volumeIDAndLocalBasePath != 0 {
+		if int(volumeOffset) >= len(data) {
+			return fmt.Errorf("the link info volume ID offset %d is out of bounds", volumeOffset)
+		}
+		info.VolumeID = new(VolumeID)
+		if err := info.VolumeID.unmarshal(data[volumeOffset:], cp); err != nil {
+			return err
+		}
+
+		path, err := readANSIZ(data, basePathOffset, cp)
+		if err != nil {
+			return fmt.Errorf("failed to read link info local base path: %v", err)
+		}
+		info.LocalBasePath = path
+
+		if unicode {
+			path, err := readUnicodeZ(data, binary.LittleEndian.Uint32(data[28:32]))
+			if err != nil {
+				return fmt.Errorf("failed to read link info unicode local base path: %v", err)
+			}
+			info.LocalBasePathUnicode = path
+		}
+	}
+
+	if flags&commonNetworkRelativeLinkAndPathSuffix != 0 {
+		if int(networkOffset) >= len(data) {
+			return fmt.Errorf("the link info network link offset %d is out of bounds", networkOffset)
+		}
+		info.NetworkLink = new(NetworkLink)
+		if err := info.NetworkLink.unmarshal(data[networkOffset:], cp); err != nil {
+			return err
+		}
+	}
+
+	suffix, err := readANSIZ(data, suffixOffset, cp)
+	if err != nil {
+		return fmt.Errorf("failed to read link info common path suffix: %v", err)
+	}
+	info.CommonPathSuffix = suffix
+
+	if unicode {
+		suffix, err := readUnicodeZ(data, binary.LittleEndian.Uint32(data[32:36]))
+		if err != nil {
+			return fmt.Errorf("failed to read link info unicode common path suffix: %v", err)
+		}
+		info.CommonPathSuffixUnicode = suffix
+	}
+
+	return nil
+}
+
+// MarshalBinary returns the binary representation of the LinkInfo
+// structure. Strings that are not stored as Unicode are encoded as
+// Windows-1252.
+func (info LinkInfo) MarshalBinary() ([]byte, error) {
+	return info.marshal(CodePageDefault)
+}
+
+// marshal returns the binary representation of a LinkInfo structure,
+// encoding strings that are not stored as Unicode with the given code page.
+func (info LinkInfo) marshal(cp CodePage) ([]byte, error) {
+	unicode := info.LocalBasePathUnicode != "" || info.CommonPathSuffixUnicode != ""
+
+	headerSize := linkInfoHeaderSize
+	if unicode {
+		headerSize = linkInfoUnicodeHeaderSize
+	}
+
+	data := make([]byte, headerSize)
+	binary.LittleEndian.PutUint32(data[4:8], uint32(headerSize))
+
+	var flags uint32
+
+	if info.VolumeID != nil {
+		flags |= volumeIDAndLocalBasePath
+
+		volume, err := info.VolumeID.marshal(cp)
+		if err != nil {
+			return nil, err
+		}
+		binary.LittleEndian.PutUint32(data[12:16], uint32(len(data)))
+		data = append(data, volume...)
+
+		binary.LittleEndian.PutUint32(data[16:20], uint32(len(data)))
+		path, err := encodeANSIZ(info.LocalBasePath, cp)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, path...)
+	}
+
+	if info.NetworkLink != nil {
+		flags |= commonNetworkRelativeLinkAndPathSuffix
+
+		network, err := info.NetworkLink.marshal(cp)
+		if err != nil {
+			return nil, err
+		}
+		binary.LittleEndian.PutUint32(data[20:24], uint32(len(data)))
+		data = append(data, network...)
+	}
+
+	binary.LittleEndian.PutUint32(data[24:28], uint32(len(data)))
+	suffix, err := encodeANSIZ(info.CommonPathSuffix, cp)
+	if err != nil {
+		return nil, err
+	}
+	data = append(data, suffix...)
+
+	if unicode {
+		if info.VolumeID != nil {
+			binary.LittleEndian.PutUint32(data[28:32], uint32(len(data)))
+			data = append(data, utf16le.EncodeZ(info.LocalBasePathUnicode)...)
+		}
+		binary.LittleEndian.PutUint32(data[32:36], uint32(len(data)))
+		data = append(data, utf16le.EncodeZ(info.CommonPathSuffixUnicode)...)
+	}
+
+	binary.LittleEndian.PutUint32(data[0:4], uint32(len(data)))
+	binary.LittleEndian.PutUint32(data[8:12], flags)
+
+	return data, nil
+}
+
+// UnmarshalBinary parses a VolumeID structure from data. Strings that are
+// not stored as Unicode are decoded as Windows-1252.
+func (v *VolumeID) UnmarshalBinary(data []byte) error {
+	return v.unmarshal(data, CodePageDefault)
+}
+
+// unmarshal parses a VolumeID structure from data, decoding strings that
+// are not stored as Unicode with the given code page.
+func (v *VolumeID) unmarshal(data []byte, cp CodePage) error {
+	if len(data) < volumeIDHeaderSize+1 {
+		return fmt.Errorf("the volume ID structure requires at least %d bytes, but only %d bytes are present", volumeIDHeaderSize+1, len(data))
+	}
+
+	size := binary.LittleEndian.Uint32(data[0:4])
+	if int(size) > len(data) || size <= volumeIDHeaderSize {
+		return fmt.Errorf("the volume ID structure declares an invalid size of %d bytes", size)
+	}
+	data = data[:size]
+
+	*v = VolumeID{
+		DriveType:    DriveType(binary.LittleEndian.Uint32(data[4:8])),
+		SerialNumber: binary.LittleEndian.Uint32(data[8:12]),
+	}
+
+	// A label offset of 0x14 indicates that the label is stored as
+	// Unicode at the offset that follows
+	if labelOffset := binary.LittleEndian.Uint32(data[12:16]); labelOffset == volumeIDUnicodeHeaderSize {
+		if len(data) < volumeIDUnicodeHeaderSize {
+			return fmt.Errorf("the volume ID structure is too small to hold a unicode volume label offset")
+		}
+		label, err := readUnicodeZ(data, binary.LittleEndian.Uint32(data[16:20]))
+		if err != nil {
+			return fmt.Errorf("failed to read unicode volume label: %v", err)
+		}
+		v.LabelUnicode = label
+	} else {
+		label, err := readANSIZ(data, labelOffset, cp)
+		if err != nil {
+			return fmt.Errorf("failed to read volume label: %v", err)
+		}
+		v.Label = label
+	}
+
+	return nil
+}
+
+// MarshalBinary returns the binary representation of the VolumeID
+// structure. The ANSI label is encoded as Windows-1252.
+func (v VolumeID) MarshalBinary() ([]byte, error) {
+	return v.marshal(CodePageDefault)
+}
+
+// marshal returns the binary representation of a VolumeID structure,
+// encoding strings that are not stored as Unicode with the given code page.
+func (v VolumeID) marshal(cp CodePage) ([]byte, error) {
+	var data []byte
+	if v.LabelUnicode != "" {
+		data = make([]byte, volumeIDUnicodeHeaderSize)
+		binary.LittleEndian.PutUint32(data[12:16], volumeIDUnicodeHeaderSize)
+		binary.LittleEndian.PutUint32(data[16:20], volumeIDUnicodeHeaderSize)
+		data = append(data, utf16le.EncodeZ(v.LabelUnicode)...)
+	} else {
+		data = make([]byte, volumeIDHeaderSize)
+		binary.LittleEndian.PutUint32(data[12:16], volumeIDHeaderSize)
+		label, err := encodeANSIZ(v.Label, cp)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, label...)
+	}
+
+	binary.LittleEndian.PutUint32(data[0:4], uint32(len(data)))
+	binary.LittleEndian.PutUint32(data[4:8], uint32(v.DriveType))
+	binary.LittleEndian.PutUint32(data[8:12], v.SerialNumber)
+
+	return data, nil
+}
+
+// UnmarshalBinary parses a CommonNetworkRelativeLink structure from
+// data. Strings that are not stored as Unicode are decoded as
+// Windows-1252.
+func (n *NetworkLink) UnmarshalBinary(data []byte) error {
+	return n.unmarshal(data, CodePageDefault)
+}
+
+// unmarshal parses a CommonNetworkRelativeLink structure from data,
+// decoding strings that are not stored as Unicode with the given code
+// page.
+func (n *NetworkLink) unmarshal(data []byte, cp CodePage) error {
+	if len(data) < networkLinkHeaderSize {
+		return fmt.Errorf("the network link structure requires at least %d bytes, but only %d bytes are present", networkLinkHeaderSize, len(data))
+	}
+
+	size := binary.LittleEndian.Uint32(data[0:4])
+	if int(size) > len(data) || size < networkLinkHeaderSize {
+		return fmt.Errorf("the network link structure declares an invalid size of %d bytes", size)
+	}
+	data = data[:size]
+
+	var (
+		flags            = binary.LittleEndian.Uint32(data[4:8])
+		netNameOffset    = binary.LittleEndian.Uint32(data[8:12])
+		deviceNameOffset = binary.LittleEndian.Uint32(data[12:16])
+	)
+
+	*n = NetworkLink{}
+
+	if flags&validNetType != 0 {
+		n.ProviderType = NetworkProviderType(binary.LittleEndian.Uint32(data[16:20]))
+	}
+
+	name, err := readANSIZ(data, netNameOffset, cp)
+	if err != nil {
+		return fmt.Errorf("failed to read network link net name: %v", err)
+	}
+	n.NetName = name
+
+	if flags&validDevice != 0 {
+		device, err := readANSIZ(data, deviceNameOffset, cp)
+		if err != nil {
+			return fmt.Errorf("failed to read network link device name: %v", err)
+		}
+		n.DeviceName = device
+	}
+
+	// A net name offset beyond 0x14 indicates that unicode offsets follow
+	if netNameOffset > networkLinkHeaderSize {
+		if len(data) < networkLinkUnicodeHeaderSize {
+			return fmt.Errorf("the network link structure is too small to hold unicode offsets")
+		}
+
+		name, err := readUnicodeZ(data, binary.LittleEndian.Uint32(data[20:24]))
+		if err != nil {
+			return fmt.Errorf("failed to read network link unicode net name: %v", err)
+		}
+		n.NetNameUnicode = name
+
+		if flags&validDevice != 0 {
+			device, err := readUnicodeZ(data, binary.LittleEndian.Uint32(data[24:28]))
+			if err != nil {
+				return fmt.Errorf("failed to read network link unicode device name: %v", err)
+			}
+			n.DeviceNameUnicode = device
+		}
+	}
+
+	return nil
+}
+
+// MarshalBinary returns the binary representation of the
+// CommonNetworkRelativeLink structure.
+//
+// If either of the Unicode names is non-empty, both Unicode names are
+// written. The ANSI names are encoded as Windows-1252.
+func (n NetworkLink) MarshalBinary() ([]byte, error) {
+	return n.marshal(CodePageDefault)
+}
+
+// marshal returns the binary representation of a
+// CommonNetworkRelativeLink structure, encoding strings that are not
+// stored as Unicode with the given code page.
+func (n NetworkLink) marshal(cp CodePage) ([]byte, error) {
+	unicode := n.NetNameUnicode != "" || n.DeviceNameUnicode != ""
+	device := n.DeviceName != "" || n.DeviceNameUnicode != ""
+
+	headerSize := networkLinkHeaderSize
+	if unicode {
+		headerSize = networkLinkUnicodeHeaderSize
+	}
+
+	data := make([]byte, headerSize)
+
+	var flags uint32
+	if n.ProviderType != 0 {
+		flags |= validNetType
+		binary.LittleEndian.PutUint32(data[16:20], uint32(n.ProviderType))
+	}
+
+	binary.LittleEndian.PutUint32(data[8:12], uint32(len(data)))
+	name, err := encodeANSIZ(n.NetName, cp)
+	if err != nil {
+		return nil, err
+	}
+	data = append(data, name...)
+
+	if device {
+		flags |= validDevice
+		binary.LittleEndian.PutUint32(data[12:16], uint32(len(data)))
+		device, err := encodeANSIZ(n.DeviceName, cp)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, device...)
+	}
+
+	if unicode {
+		binary.LittleEndian.PutUint32(data[20:24], uint32(len(data)))
+		data = append(data, utf16le.EncodeZ(n.NetNameUnicode)...)
+		if device {
+			binary.LittleEndian.PutUint32(data[24:28], uint32(len(data)))
+			data = append(data, utf16le.EncodeZ(n.DeviceNameUnicode)...)
+		}
+	}
+
+	binary.LittleEndian.PutUint32(data[0:4], uint32(len(data)))
+	binary.LittleEndian.PutUint32(data[4:8], flags)
+
+	return data, nil
+}
+
+// readUnicodeZ reads a null-terminated UTF-16 string from data at the
+// given offset.
+func readUnicodeZ(data []byte, offset uint32) (string, error) {
+	if int(offset) >= len(data) {
+		return "", fmt.Errorf("string offset %d is beyond the end of its %d byte structure", offset, len(data))
+	}
+	s, _, ok := utf16le.DecodeZ(data[offset:])
+	if !ok {
+		return "", fmt.Errorf("string at offset %d is not null-terminated", offset)
+	}
+	return s, nil
+}
+
+// prefer returns preferred if it is non-empty, otherwise it returns
+// fallback.
+func prefer(preferred, fallback string) string {
+	if preferred != "" {
+		return preferred
+	}
+	return fallback
+}
@@ -0,0 +1,90 @@
+package shelllink_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gentlemanautomaton/winshell/shelllink"
+)
+
+func TestLinkInfoRoundTrip(t *testing.T) {
+	tests := []struct {
+		Name string
+		Info shelllink.LinkInfo
+		Path string
+	}{
+		{
+			Name: "Local",
+			Info: shelllink.LinkInfo{
+				VolumeID: &shelllink.VolumeID{
+					DriveType:    shelllink.DriveFixed,
+					SerialNumber: 0x1234ABCD,
+					Label:        "Windows",
+				},
+				LocalBasePath: `C:\test\a.txt`,
+			},
+			Path: `C:\test\a.txt`,
+		},
+		{
+			Name: "LocalUnicode",
+			Info: shelllink.LinkInfo{
+				VolumeID: &shelllink.VolumeID{
+					DriveType:    shelllink.DriveRemovable,
+					SerialNumber: 0xCAFEF00D,
+					LabelUnicode: "Ünïcödé",
+				},
+				LocalBasePath:           `E:\`,
+				LocalBasePathUnicode:    `E:\`,
+				CommonPathSuffix:        `f?r`,
+				CommonPathSuffixUnicode: `för`,
+			},
+			Path: `E:\för`,
+		},
+		{
+			Name: "Network",
+			Info: shelllink.LinkInfo{
+				NetworkLink: &shelllink.NetworkLink{
+					NetName:      `\\SERVER\SHARE`,
+					DeviceName:   `Z:`,
+					ProviderType: shelllink.NetworkLanman,
+				},
+				CommonPathSuffix: `docs\a.txt`,
+			},
+			Path: `\\SERVER\SHARE\docs\a.txt`,
+		},
+		{
+			Name: "NetworkUnicode",
+			Info: shelllink.LinkInfo{
+				NetworkLink: &shelllink.NetworkLink{
+					NetName:           `\\SERVER\SH?RE`,
+					NetNameUnicode:    `\\SERVER\SHÅRE`,
+					DeviceName:        `Y:`,
+					DeviceNameUnicode: `Y:`,
+					ProviderType:      shelllink.NetworkLanman,
+				},
+				CommonPathSuffix:        `docs`,
+				CommonPathSuffixUnicode: `docs`,
+			},
+			Path: `\\SERVER\SHÅRE\docs`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			data, err := test.Info.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var info shelllink.LinkInfo
+			if err := info.UnmarshalBinary(data); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(info, test.Info) {
+				t.Errorf("round trip mismatch:\n got %+v\nwant %+v", info, test.Info)
+			}
+			if got := info.Path(); got != test.Path {
+				t.Errorf("path: got %q, want %q", got, test.Path)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package shelllink
+
+import "fmt"
+
+// NetworkProviderType identifies the network provider that hosts a link
+// target on a network share.
+type NetworkProviderType uint32
+
+// Network provider types.
+const (
+	NetworkLanman      NetworkProviderType = 0x00020000 // WNNC_NET_LANMAN
+	NetworkNetWare     NetworkProviderType = 0x00030000 // WNNC_NET_NETWARE
+	NetworkAvid        NetworkProviderType = 0x001A0000 // WNNC_NET_AVID
+	NetworkDocuSpace   NetworkProviderType = 0x001B0000 // WNNC_NET_DOCUSPACE
+	NetworkMangoSoft   NetworkProviderType = 0x001C0000 // WNNC_NET_MANGOSOFT
+	NetworkSernet      NetworkProviderType = 0x001D0000 // WNNC_NET_SERNET
+	NetworkRiverFront1 NetworkProviderType = 0x001E0000 // WNNC_NET_RIVERFRONT1
+	NetworkRiverFront2 NetworkProviderType = 0x001F0000 // WNNC_NET_RIVERFRONT2
+	NetworkDecorb      NetworkProviderType = 0x00200000 // WNNC_NET_DECORB
+	NetworkProtstor    NetworkProviderType = 0x00210000 // WNNC_NET_PROTSTOR
+	NetworkFJRedir     NetworkProviderType = 0x00220000 // WNNC_NET_FJ_REDIR
+	NetworkDistinct    NetworkProviderType = 0x00230000 // WNNC_NET_DISTINCT
+	NetworkTwins       NetworkProviderType = 0x00240000 // WNNC_NET_TWINS
+	NetworkRDR2Sample  NetworkProviderType = 0x00250000 // WNNC_NET_RDR2SAMPLE
+	NetworkCSC         NetworkProviderType = 0x00260000 // WNNC_NET_CSC
+	Network3In1        NetworkProviderType = 0x00270000 // WNNC_NET_3IN1
+	NetworkExtendNet   NetworkProviderType = 0x00290000 // WNNC_NET_EXTENDNET
+	NetworkStac        NetworkProviderType = 0x002A0000 // WNNC_NET_STAC
+	NetworkFoxbat      NetworkProviderType = 0x002B0000 // WNNC_NET_FOXBAT
+	NetworkYahoo       NetworkProviderType = 0x002C0000 // WNNC_NET_YAHOO
+	NetworkExifs       NetworkProviderType = 0x002D0000 // WNNC_NET_EXIFS
+	NetworkDAV         NetworkProviderType = 0x002E0000 // WNNC_NET_DAV
+	NetworkKnoware     NetworkProviderType = 0x002F0000 // WNNC_NET_KNOWARE
+	NetworkObjectDire  NetworkProviderType = 0x00300000 // WNNC_NET_OBJECT_DIRE
+	NetworkMasfax      NetworkProviderType = 0x00310000 // WNNC_NET_MASFAX
+	NetworkHobNFS      NetworkProviderType = 0x00320000 // WNNC_NET_HOB_NFS
+	NetworkShiva       NetworkProviderType = 0x00330000 // WNNC_NET_SHIVA
+	NetworkIBMAL       NetworkProviderType = 0x00340000 // WNNC_NET_IBMAL
+	NetworkLock        NetworkProviderType = 0x00350000 // WNNC_NET_LOCK
+	NetworkTermSrv     NetworkProviderType = 0x00360000 // WNNC_NET_TERMSRV
+	NetworkSRT         NetworkProviderType = 0x00370000 // WNNC_NET_SRT
+	NetworkQuincy      NetworkProviderType = 0x00380000 // WNNC_NET_QUINCY
+	NetworkOpenAFS     NetworkProviderType = 0x00390000 // WNNC_NET_OPENAFS
+	NetworkAvid1       NetworkProviderType = 0x003A0000 // WNNC_NET_AVID1
+	NetworkDFS         NetworkProviderType = 0x003B0000 // WNNC_NET_DFS
+	NetworkKWNP        NetworkProviderType = 0x003C0000 // WNNC_NET_KWNP
+	NetworkZenworks    NetworkProviderType = 0x003D0000 // WNNC_NET_ZENWORKS
+	NetworkDriveOnWeb  NetworkProviderType = 0x003E0000 // WNNC_NET_DRIVEONWEB
+	NetworkVMware      NetworkProviderType = 0x003F0000 // WNNC_NET_VMWARE
+	NetworkRSFX        NetworkProviderType = 0x00400000 // WNNC_NET_RSFX
+	NetworkMFiles      NetworkProviderType = 0x00410000 // WNNC_NET_MFILES
+	NetworkMSNFS       NetworkProviderType = 0x00420000 // WNNC_NET_MS_NFS
+	NetworkGoogle      NetworkProviderType = 0x00430000 // WNNC_NET_GOOGLE
+)
+
+var networkProviderNames = map[NetworkProviderType]string{
+	NetworkLanman:      "WNNC_NET_LANMAN",
+	NetworkNetWare:     "WNNC_NET_NETWARE",
+	NetworkAvid:        "WNNC_NET_AVID",
+	NetworkDocuSpace:   "WNNC_NET_DOCUSPACE",
+	NetworkMangoSoft:   "WNNC_NET_MANGOSOFT",
+	NetworkSernet:      "WNNC_NET_SERNET",
+	NetworkRiverFront1: "WNNC_NET_RIVERFRONT1",
+	NetworkRiverFront2: "WNNC_NET_RIVERFRONT2",
+	NetworkDecorb:      "WNNC_NET_DECORB",
+	NetworkProtstor:    "WNNC_NET_PROTSTOR",
+	NetworkFJRedir:     "WNNC_NET_FJ_REDIR",
+	NetworkDistinct:    "WNNC_NET_DISTINCT",
+	NetworkTwins:       "WNNC_NET_TWINS",
+	NetworkRDR2Sample:  "WNNC_NET_RDR2SAMPLE",
+	NetworkCSC:         "WNNC_NET_CSC",
+	Network3In1:        "WNNC_NET_3IN1",
+	NetworkExtendNet:   "WNNC_NET_EXTENDNET",
+	NetworkStac:        "WNNC_NET_STAC",
+	NetworkFoxbat:      "WNNC_NET_FOXBAT",
+	NetworkYahoo:       "WNNC_NET_YAHOO",
+	NetworkExifs:       "WNNC_NET_EXIFS",
+	NetworkDAV:         "WNNC_NET_DAV",
+	NetworkKnoware:     "WNNC_NET_KNOWARE",
+	NetworkObjectDire:  "WNNC_NET_OBJECT_DIRE",
+	NetworkMasfax:      "WNNC_NET_MASFAX",
+	NetworkHobNFS:      "WNNC_NET_HOB_NFS",
+	NetworkShiva:       "WNNC_NET_SHIVA",
+	NetworkIBMAL:       "WNNC_NET_IBMAL",
+	NetworkLock:        "WNNC_NET_LOCK",
+	NetworkTermSrv:     "WNNC_NET_TERMSRV",
+	NetworkSRT:         "WNNC_NET_SRT",
+	NetworkQuincy:      "WNNC_NET_QUINCY",
+	NetworkOpenAFS:     "WNNC_NET_OPENAFS",
+	NetworkAvid1:       "WNNC_NET_AVID1",
+	NetworkDFS:         "WNNC_NET_DFS",
+	NetworkKWNP:        "WNNC_NET_KWNP",
+	NetworkZenworks:    "WNNC_NET_ZENWORKS",
+	NetworkDriveOnWeb:  "WNNC_NET_DRIVEONWEB",
+	NetworkVMware:      "WNNC_NET_VMWARE",
+	NetworkRSFX:        "WNNC_NET_RSFX",
+	NetworkMFiles:      "WNNC_NET_MFILES",
+	NetworkMSNFS:       "WNNC_NET_MS_NFS",
+	NetworkGoogle:      "WNNC_NET_GOOGLE",
+}
+
+// String returns the name of the network provider type.
+func (t NetworkProviderType) String() string {
+	if name, ok := networkProviderNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("NetworkProviderType(%#x)", uint32(t))
+}
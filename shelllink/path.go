@@ -1,48 +1,93 @@
-// +build windows
-
 package shelllink
 
 import (
 	"fmt"
+	"strings"
 
-	"github.com/gentlemanautomaton/winshell/shobjidl"
-	"github.com/scjalliance/comshim"
+	"github.com/gentlemanautomaton/winshell/shellns"
+	"github.com/gentlemanautomaton/winshell/winpath"
 )
 
 // Path is a file system path that can be marshaled as a shell link.
 type Path string
 
-// MarshalBinary returns a binary representation of a shell link for the path.
-func (p Path) MarshalBinary() ([]byte, error) {
-	// Make sure COM is initialized for the duration of the work
-	comshim.Add(1)
-	defer comshim.Done()
+// Link returns a shell link for the path without relying on the component
+// object model. It can be used on any operating system.
+//
+// Paths that begin with a drive letter produce a link with a target ID
+// list and local link info. UNC paths produce a link with network link
+// info only. The path is cleaned by winpath.Clean before it is encoded,
+// so forward slashes, repeated separators and "." and ".." elements are
+// resolved as Windows would resolve them. A trailing separator marks the
+// final element as a directory.
+//
+// The link does not include file attributes, sizes or timestamps for the
+// target, since the target is not examined.
+func (p Path) Link() (Link, error) {
+	path := winpath.Clean(string(p))
 
-	// Prepare a new IShellLink COM instance
-	link, err := shobjidl.NewIShellLink()
-	if err != nil {
-		return nil, fmt.Errorf("failed to prepare IShellLink COM interface: %v", err)
+	link := Link{
+		Header: Header{
+			Flags:       IsUnicode,
+			ShowCommand: ShowNormal,
+		},
 	}
-	defer link.Release()
 
-	// Set the path for the shortcut
-	if err := link.SetPath(string(p)); err != nil {
-		return nil, fmt.Errorf("failed to set shell link path: %v", err)
+	switch winpath.Classify(path) {
+	case winpath.DriveAbsolute:
+		target := path
+		if original := string(p); original != "" && winpath.IsSeparator(original[len(original)-1]) && !strings.HasSuffix(path, `\`) {
+			target += `\`
+		}
+		list, err := shellns.FromPath(target, shellns.PathOptions{})
+		if err != nil {
+			return Link{}, err
+		}
+		link.IDList = list
+		link.LinkInfo = &LinkInfo{
+			VolumeID:      &VolumeID{DriveType: DriveFixed},
+			LocalBasePath: path,
+		}
+		if !isASCII(path) {
+			link.LinkInfo.LocalBasePathUnicode = path
+		}
+	case winpath.UNC:
+		share, suffix := splitUNC(path)
+		if share == "" {
+			return Link{}, fmt.Errorf("the UNC path \"%s\" does not include a share name", path)
+		}
+		link.LinkInfo = &LinkInfo{
+			NetworkLink:      &NetworkLink{NetName: strings.ToUpper(share), ProviderType: NetworkLanman},
+			CommonPathSuffix: suffix,
+		}
+		if !isASCII(path) {
+			link.LinkInfo.NetworkLink.NetNameUnicode = strings.ToUpper(share)
+			link.LinkInfo.CommonPathSuffixUnicode = suffix
+		}
+	default:
+		return Link{}, fmt.Errorf("the path \"%s\" is not an absolute drive letter or UNC path", path)
 	}
 
-	// Ask the link for an IPersistStream COM interface that we can use
-	// to serialize its data
-	stream, err := link.PersistStream()
-	if err != nil {
-		return nil, fmt.Errorf("failed to prepare IPersistStream COM interface: %v", err)
-	}
-	defer stream.Release()
+	return link, nil
+}
 
-	// Write the link's data to an in-memory buffer
-	buf := shobjidl.NewStreamBuffer()
-	if err := stream.Save(buf.IStream()); err != nil {
-		return nil, fmt.Errorf("failed to save shell link data to stream buffer: %v", err)
+// splitUNC splits a UNC path into its \\server\share volume and the
+// remainder of the path. It returns an empty share if the volume lacks a
+// server or share name.
+func splitUNC(path string) (share, suffix string) {
+	share = winpath.Volume(path)
+	if server, name, _ := strings.Cut(share[2:], `\`); server == "" || name == "" {
+		return "", ""
 	}
+	return share, strings.TrimPrefix(path[len(share):], `\`)
+}
 
-	return buf.Bytes(), nil
+// isASCII returns true if s contains only ASCII characters.
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
 }
@@ -0,0 +1,15 @@
+//go:build !windows
+// +build !windows
+
+package shelllink
+
+// MarshalBinary returns a binary representation of a shell link for the path.
+//
+// The link is produced by the pure Go encoder. See Path.Link for details.
+func (p Path) MarshalBinary() ([]byte, error) {
+	link, err := p.Link()
+	if err != nil {
+		return nil, err
+	}
+	return link.MarshalBinary()
+}
@@ -0,0 +1,126 @@
+package shelllink
+
+import (
+	"fmt"
+
+	"github.com/gentlemanautomaton/winshell/propkey"
+	"github.com/gentlemanautomaton/winshell/propstore"
+	"github.com/gentlemanautomaton/winshell/propvariant"
+	"github.com/google/uuid"
+)
+
+// PropertyStoreData holds properties of a shell link, such as its
+// application user model ID. It corresponds to a PropertyStoreDataBlock.
+//
+// VT_LPSTR property values are encoded with the link's code page.
+type PropertyStoreData struct {
+	Store propstore.Store
+}
+
+// Signature returns the signature of the block.
+func (p PropertyStoreData) Signature() Signature {
+	return PropertyStoreDataBlock
+}
+
+// UnmarshalBinary parses a property store from data, which excludes the
+// block's size and signature. VT_LPSTR values are decoded as
+// Windows-1252.
+func (p *PropertyStoreData) UnmarshalBinary(data []byte) error {
+	return p.unmarshal(data, CodePageDefault)
+}
+
+// unmarshal parses a property store from data, decoding VT_LPSTR values
+// with cp.
+func (p *PropertyStoreData) unmarshal(data []byte, cp CodePage) error {
+	enc, err := cp.encoding()
+	if err != nil {
+		return err
+	}
+	return p.Store.UnmarshalANSI(data, enc)
+}
+
+// MarshalBinary returns the binary representation of the property store,
+// excluding the block's size and signature. VT_LPSTR values are encoded
+// as Windows-1252.
+func (p PropertyStoreData) MarshalBinary() ([]byte, error) {
+	return p.marshal(CodePageDefault)
+}
+
+// marshal returns the binary representation of the property store,
+// encoding VT_LPSTR values with cp.
+func (p PropertyStoreData) marshal(cp CodePage) ([]byte, error) {
+	enc, err := cp.encoding()
+	if err != nil {
+		return nil, err
+	}
+	return p.Store.MarshalANSI(enc)
+}
+
+// Property returns the value of a property held in the link's property
+// store data block. It returns false if the link does not hold the
+// property.
+func (link Link) Property(key propkey.PropertyKey) (propvariant.Value, bool) {
+	switch block := link.ExtraData.Get(PropertyStoreDataBlock).(type) {
+	case *PropertyStoreData:
+		return block.Store.Get(key)
+	case PropertyStoreData:
+		return block.Store.Get(key)
+	default:
+		return propvariant.Value{}, false
+	}
+}
+
+// SetProperty stores the value of a property in the link's property
+// store data block, adding the block if necessary. It returns an error
+// if the link holds a property store data block that could not be
+// decoded.
+func (link *Link) SetProperty(key propkey.PropertyKey, value propvariant.Value) error {
+	var block *PropertyStoreData
+	switch existing := link.ExtraData.Get(PropertyStoreDataBlock).(type) {
+	case nil:
+		block = new(PropertyStoreData)
+	case *PropertyStoreData:
+		block = existing
+	case PropertyStoreData:
+		block = &existing
+	default:
+		return fmt.Errorf("the link's %s could not be decoded", PropertyStoreDataBlock)
+	}
+	block.Store.Set(key, value)
+	link.ExtraData.Set(block)
+	return nil
+}
+
+// AppUserModelID returns the System.AppUserModel.ID property of the link,
+// which associates the link with an application's taskbar button and
+// notifications. It returns false if the link does not have one.
+func (link Link) AppUserModelID() (string, bool) {
+	value, ok := link.Property(propkey.AppUserModelID)
+	if !ok {
+		return "", false
+	}
+	return value.AsString()
+}
+
+// SetAppUserModelID sets the System.AppUserModel.ID property of the link.
+func (link *Link) SetAppUserModelID(id string) error {
+	return link.SetProperty(propkey.AppUserModelID, propvariant.NewString(id))
+}
+
+// ToastActivatorCLSID returns the System.AppUserModel.ToastActivatorCLSID
+// property of the link, which identifies the COM class that is activated
+// when a user interacts with the application's notifications. It returns
+// false if the link does not have one.
+func (link Link) ToastActivatorCLSID() (uuid.UUID, bool) {
+	value, ok := link.Property(propkey.AppUserModelToastActivatorCLSID)
+	if !ok {
+		return uuid.Nil, false
+	}
+	return value.AsGUID()
+}
+
+// SetToastActivatorCLSID sets the System.AppUserModel.ToastActivatorCLSID
+// property of the link.
+func (link *Link) SetToastActivatorCLSID(id uuid.UUID) error {
+	return link.SetProperty(propkey.AppUserModelToastActivatorCLSID, propvariant.NewGUID(id))
+}
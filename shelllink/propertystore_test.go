@@ -0,0 +1,49 @@
+package shelllink_test
+
+import (
+	"testing"
+
+	"github.com/gentlemanautomaton/winshell/shelllink"
+	"github.com/google/uuid"
+)
+
+func TestLinkAppUserModelProperties(t *testing.T) {
+	activator := uuid.MustParse("5A8E3C1F-1E0B-4C35-9E1B-7A0F6C2D9E40")
+
+	source := shelllink.Link{Header: shelllink.Header{Flags: shelllink.IsUnicode}}
+	if err := source.SetAppUserModelID("Contoso.App"); err != nil {
+		t.Fatal(err)
+	}
+	if err := source.SetToastActivatorCLSID(activator); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := source.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var link shelllink.Link
+	if err := link.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := link.ExtraData.Get(shelllink.PropertyStoreDataBlock).(*shelllink.PropertyStoreData); !ok {
+		t.Fatalf("the property store block was not decoded: %#v", link.ExtraData)
+	}
+	if id, ok := link.AppUserModelID(); !ok || id != "Contoso.App" {
+		t.Errorf("app user model ID: got %q (%t)", id, ok)
+	}
+	if id, ok := link.ToastActivatorCLSID(); !ok || id != activator {
+		t.Errorf("toast activator: got %v (%t)", id, ok)
+	}
+
+	if err := link.SetAppUserModelID("Contoso.App.2"); err != nil {
+		t.Fatal(err)
+	}
+	if len(link.ExtraData) != 1 {
+		t.Errorf("updating a property added a block")
+	}
+	if id, _ := link.AppUserModelID(); id != "Contoso.App.2" {
+		t.Errorf("updated app user model ID: got %q", id)
+	}
+}
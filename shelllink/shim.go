@@ -0,0 +1,97 @@
+package shelllink
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/gentlemanautomaton/winshell/internal/utf16le"
+	"github.com/gentlemanautomaton/winshell/shellns"
+)
+
+// shimDataMinSize is the minimum number of bytes in a ShimDataBlock,
+// excluding its size and signature.
+const shimDataMinSize = 0x88 - blockHeaderSize
+
+// ShimData specifies the name of a compatibility layer that is applied
+// to the target of a shell link when it is launched. It corresponds to
+// a ShimDataBlock.
+//
+// Link.MarshalBinary sets the RunWithShimLayer flag when a link has this
+// block, and clears it when the link does not.
+type ShimData struct {
+	// LayerName is the name of the shim layer, such as WIN7RTM or
+	// RUNASADMIN.
+	LayerName string
+
+	raw []byte
+}
+
+// Signature returns the signature of the block.
+func (s ShimData) Signature() Signature {
+	return ShimDataBlock
+}
+
+// UnmarshalBinary parses shim data from data, which excludes the block's
+// size and signature.
+func (s *ShimData) UnmarshalBinary(data []byte) error {
+	if len(data) < shimDataMinSize {
+		return fmt.Errorf("the %s requires at least %d bytes of data, but only %d bytes are present", ShimDataBlock, shimDataMinSize, len(data))
+	}
+	*s = ShimData{
+		LayerName: decodeFixedUnicode(data),
+		raw:       bytes.Clone(data),
+	}
+	return nil
+}
+
+// MarshalBinary returns the binary representation of the shim data,
+// excluding the block's size and signature. The layer name is padded
+// with zeros to the minimum size of the block.
+func (s ShimData) MarshalBinary() ([]byte, error) {
+	if s.raw != nil && decodeFixedUnicode(s.raw) == s.LayerName {
+		return bytes.Clone(s.raw), nil
+	}
+	data := utf16le.EncodeZ(s.LayerName)
+	if len(data) < shimDataMinSize {
+		data = append(data, make([]byte, shimDataMinSize-len(data))...)
+	}
+	return data, nil
+}
+
+// VistaAndAboveIDListData holds an alternate target ID list that is used
+// instead of the link's target ID list on Windows Vista and later. It
+// corresponds to a VistaAndAboveIDListDataBlock.
+type VistaAndAboveIDListData struct {
+	IDList shellns.List
+}
+
+// Signature returns the signature of the block.
+func (v VistaAndAboveIDListData) Signature() Signature {
+	return VistaAndAboveIDListDataBlock
+}
+
+// UnmarshalBinary parses an alternate ID list from data, which excludes
+// the block's size and signature. Unlike the link's target ID list, the
+// list is not preceded by its size.
+func (v *VistaAndAboveIDListData) UnmarshalBinary(data []byte) error {
+	if len(data) > 65535 {
+		return fmt.Errorf("the %s holds an ID list of %d bytes, which exceeds the limit of 65535", VistaAndAboveIDListDataBlock, len(data))
+	}
+	list := binary.LittleEndian.AppendUint16(make([]byte, 0, 2+len(data)), uint16(len(data)))
+	list = append(list, data...)
+	if err := v.IDList.UnmarshalBinary(list); err != nil {
+		return fmt.Errorf("failed to parse %s ID list: %v", VistaAndAboveIDListDataBlock, err)
+	}
+	return nil
+}
+
+// MarshalBinary returns the binary representation of the alternate ID
+// list, excluding the block's size and signature.
+func (v VistaAndAboveIDListData) MarshalBinary() ([]byte, error) {
+	list, err := v.IDList.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return list[2:], nil
+}
@@ -0,0 +1,61 @@
+package shelllink_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gentlemanautomaton/winshell/shelllink"
+	"github.com/gentlemanautomaton/winshell/shellns"
+)
+
+func TestShimAndVistaIDListData(t *testing.T) {
+	list, err := shellns.FromPath(`C:\Games\old.exe`, shellns.PathOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	source := shelllink.Link{
+		Header: shelllink.Header{Flags: shelllink.IsUnicode},
+		ExtraData: shelllink.ExtraData{
+			&shelllink.ShimData{LayerName: "WINXPSP3"},
+			&shelllink.VistaAndAboveIDListData{IDList: list},
+		},
+	}
+
+	data, err := source.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var link shelllink.Link
+	if err := link.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if !link.Header.Flags.Has(shelllink.RunWithShimLayer) {
+		t.Error("the RunWithShimLayer flag is not set")
+	}
+
+	shim, ok := link.ExtraData[0].(*shelllink.ShimData)
+	if !ok {
+		t.Fatalf("first block: got %T", link.ExtraData[0])
+	}
+	if shim.LayerName != "WINXPSP3" {
+		t.Errorf("layer name: got %q", shim.LayerName)
+	}
+
+	vista, ok := link.ExtraData[1].(*shelllink.VistaAndAboveIDListData)
+	if !ok {
+		t.Fatalf("second block: got %T", link.ExtraData[1])
+	}
+	if !vista.IDList.Equal(list) {
+		t.Errorf("ID list: got %s, want %s", vista.IDList.ParsingName(), list.ParsingName())
+	}
+
+	encoded, err := link.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(encoded, data) {
+		t.Error("the link did not survive a round trip")
+	}
+}
@@ -0,0 +1,113 @@
+package shelllink
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Shortcut describes a shell link in terms of the properties that are
+// shown in a shortcut's property sheet. It can be converted to a complete
+// shell link without relying on the component object model, and can be
+// used on any operating system.
+type Shortcut struct {
+	// Target is the path of the file or folder that the shortcut opens.
+	// It must be an absolute drive letter or UNC path, or a path that
+	// includes environment variables, such as %SystemRoot%\notepad.exe.
+	Target string
+
+	// Arguments are the command line arguments passed to the target.
+	Arguments string
+
+	// WorkingDirectory is the directory the target is started in.
+	WorkingDirectory string
+
+	// Description is the comment shown for the shortcut.
+	Description string
+
+	// IconLocation is the path of a file that holds the shortcut's icon,
+	// and IconIndex is the index of the icon within that file. The
+	// location may include environment variables.
+	IconLocation string
+	IconIndex    int32
+
+	// Hotkey is the keyboard shortcut that activates the shortcut.
+	Hotkey Hotkey
+
+	// ShowCommand is the window state of the started application. The
+	// zero value selects ShowNormal.
+	ShowCommand ShowCommand
+
+	// RunAsAdmin requests that the target be started with administrative
+	// privileges.
+	RunAsAdmin bool
+
+	// AppUserModelID is the application user model ID of the target,
+	// which associates the shortcut with the application's taskbar button
+	// and notifications.
+	AppUserModelID string
+}
+
+// Link returns a shell link for the shortcut.
+//
+// Targets that include environment variables are stored in an
+// environment variable data block, which Windows expands when the link
+// is resolved. Since the variables cannot be expanded on other systems,
+// such links lack a target ID list and link info. All other targets are
+// encoded as described by Path.Link.
+func (s Shortcut) Link() (Link, error) {
+	if err := s.Hotkey.Validate(); err != nil {
+		return Link{}, fmt.Errorf("invalid shortcut hotkey %s: %w", s.Hotkey, err)
+	}
+
+	var link Link
+	if hasEnvironmentVariable(s.Target) {
+		link.Header.Flags = IsUnicode
+		link.SetEnvironmentTarget(s.Target)
+	} else {
+		var err error
+		if link, err = Path(s.Target).Link(); err != nil {
+			return Link{}, err
+		}
+	}
+
+	link.Header.ShowCommand = s.ShowCommand.Effective()
+	link.Header.Hotkey = s.Hotkey
+	if s.RunAsAdmin {
+		link.Header.Flags.Set(RunAsUser)
+	}
+
+	link.StringData.Name = s.Description
+	link.StringData.WorkingDir = s.WorkingDirectory
+	link.StringData.Arguments = s.Arguments
+	if hasEnvironmentVariable(s.IconLocation) {
+		link.SetEnvironmentIcon(s.IconLocation, s.IconIndex)
+	} else {
+		link.StringData.IconLocation = s.IconLocation
+		link.Header.IconIndex = s.IconIndex
+	}
+
+	if s.AppUserModelID != "" {
+		if err := link.SetAppUserModelID(s.AppUserModelID); err != nil {
+			return Link{}, err
+		}
+	}
+
+	return link, nil
+}
+
+// MarshalBinary returns the binary representation of a shell link for
+// the shortcut.
+func (s Shortcut) MarshalBinary() ([]byte, error) {
+	link, err := s.Link()
+	if err != nil {
+		return nil, err
+	}
+	return link.MarshalBinary()
+}
+
+// hasEnvironmentVariable returns true if path includes a reference to an
+// environment variable, such as %ProgramFiles%.
+func hasEnvironmentVariable(path string) bool {
+	start := strings.IndexByte(path, '%')
+	return start >= 0 && strings.IndexByte(path[start+1:], '%') > 0
+}
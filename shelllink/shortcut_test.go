@@ -0,0 +1,118 @@
+package shelllink_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gentlemanautomaton/winshell/shelllink"
+)
+
+func TestShortcutMarshalBinary(t *testing.T) {
+	shortcut := shelllink.Shortcut{
+		Target:           `C:\Program Files\App\app.exe`,
+		Arguments:        `--profile "work"`,
+		WorkingDirectory: `C:\Users\alice`,
+		Description:      "Starts the app",
+		IconLocation:     `C:\Program Files\App\app.exe`,
+		IconIndex:        1,
+		Hotkey:           shelllink.NewHotkey('A', shelllink.HotkeyControl|shelllink.HotkeyAlt),
+		ShowCommand:      shelllink.ShowMaximized,
+		RunAsAdmin:       true,
+		AppUserModelID:   "Contoso.App",
+	}
+
+	data, err := shortcut.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var link shelllink.Link
+	if err := link.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+
+	want := shelllink.HasLinkTargetIDList | shelllink.HasLinkInfo | shelllink.HasName | shelllink.HasWorkingDir | shelllink.HasArguments | shelllink.HasIconLocation | shelllink.IsUnicode | shelllink.RunAsUser
+	if flags := link.Header.Flags; flags != want {
+		t.Errorf("flags: got %s, want %s", flags, want)
+	}
+	if link.Header.ShowCommand != shelllink.ShowMaximized {
+		t.Errorf("show command: got %s", link.Header.ShowCommand)
+	}
+	if link.Header.Hotkey != shortcut.Hotkey {
+		t.Errorf("hotkey: got %s", link.Header.Hotkey)
+	}
+	if link.Header.IconIndex != 1 {
+		t.Errorf("icon index: got %d", link.Header.IconIndex)
+	}
+	if path := link.LinkInfo.Path(); path != shortcut.Target {
+		t.Errorf("target: got %q", path)
+	}
+	if got := link.IDList.ParsingName(); got != shortcut.Target {
+		t.Errorf("ID list: got %q", got)
+	}
+
+	strings := shelllink.StringData{
+		Name:         shortcut.Description,
+		WorkingDir:   shortcut.WorkingDirectory,
+		Arguments:    shortcut.Arguments,
+		IconLocation: shortcut.IconLocation,
+	}
+	if link.StringData != strings {
+		t.Errorf("string data: got %+v", link.StringData)
+	}
+	if id, ok := link.AppUserModelID(); !ok || id != shortcut.AppUserModelID {
+		t.Errorf("app user model ID: got %q (%t)", id, ok)
+	}
+}
+
+func TestShortcutEnvironmentTarget(t *testing.T) {
+	shortcut := shelllink.Shortcut{
+		Target:       `%SystemRoot%\notepad.exe`,
+		IconLocation: `%SystemRoot%\notepad.exe`,
+	}
+
+	data, err := shortcut.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var link shelllink.Link
+	if err := link.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+
+	if flags := link.Header.Flags; !flags.Has(shelllink.HasExpString|shelllink.HasExpIcon) || flags.Has(shelllink.HasLinkTargetIDList|shelllink.HasLinkInfo) {
+		t.Errorf("flags: got %s", flags)
+	}
+	if link.Header.ShowCommand != shelllink.ShowNormal {
+		t.Errorf("show command: got %s", link.Header.ShowCommand)
+	}
+	env, ok := link.ExtraData.Get(shelllink.EnvironmentVariableDataBlock).(*shelllink.EnvironmentVariableData)
+	if !ok || env.Path() != shortcut.Target {
+		t.Errorf("environment target: got %#v", link.ExtraData.Get(shelllink.EnvironmentVariableDataBlock))
+	}
+}
+
+func TestShortcutErrors(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Shortcut shelllink.Shortcut
+	}{
+		{"RelativeTarget", shelllink.Shortcut{Target: `app.exe`}},
+		{"EmptyTarget", shelllink.Shortcut{}},
+		{"UnmodifiedHotkey", shelllink.Shortcut{Target: `C:\app.exe`, Hotkey: shelllink.NewHotkey('A', 0)}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			if _, err := test.Shortcut.MarshalBinary(); err == nil {
+				t.Error("the shortcut was marshaled without error")
+			}
+		})
+	}
+
+	_, err := shelllink.Shortcut{Target: `C:\app.exe`, Hotkey: shelllink.NewHotkey('A', shelllink.HotkeyShift)}.Link()
+	if !errors.Is(err, shelllink.ErrHotkeyCombo) {
+		t.Errorf("hotkey error: got %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package shelllink
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ShowCommand is the expected window state of an application launched by
+// a shell link.
+//
+// Only ShowNormal, ShowMaximized and ShowMinNoActive are valid for shell
+// links, and all other values are treated as ShowNormal. The value read
+// from a link is retained as-is so that it can be written back unchanged.
+// Effective reports the window state that Windows actually applies.
+//
+// https://docs.microsoft.com/en-us/openspecs/windows_protocols/ms-shllink/c3376b21-0931-45e4-b2fc-a48ac0e60d15
+type ShowCommand uint32
+
+// Show commands supported by shell links.
+const (
+	ShowNormal      ShowCommand = 1 // SW_SHOWNORMAL
+	ShowMaximized   ShowCommand = 3 // SW_SHOWMAXIMIZED
+	ShowMinNoActive ShowCommand = 7 // SW_SHOWMINNOACTIVE
+)
+
+var showCommandNames = map[ShowCommand]string{
+	ShowNormal:      "Normal",
+	ShowMaximized:   "Maximized",
+	ShowMinNoActive: "MinNoActive",
+}
+
+var showCommandAliases = map[string]ShowCommand{
+	"sw_shownormal":      ShowNormal,
+	"sw_showmaximized":   ShowMaximized,
+	"sw_maximize":        ShowMaximized,
+	"sw_showminnoactive": ShowMinNoActive,
+}
+
+// Effective returns the show command that Windows applies when it
+// launches a link with c. Values other than ShowMaximized and
+// ShowMinNoActive map to ShowNormal.
+func (c ShowCommand) Effective() ShowCommand {
+	switch c {
+	case ShowMaximized, ShowMinNoActive:
+		return c
+	default:
+		return ShowNormal
+	}
+}
+
+// String returns the name of the show command. Undocumented values are
+// returned in the form "ShowCommand(n)".
+func (c ShowCommand) String() string {
+	if name, ok := showCommandNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("ShowCommand(%d)", uint32(c))
+}
+
+// MarshalText implements encoding.TextMarshaler. It returns the same
+// text as String, so undocumented values are retained.
+func (c ShowCommand) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts the
+// text returned by String as well as SW_* constant names and numeric
+// values, without regard to case. Values are retained as-is.
+func (c *ShowCommand) UnmarshalText(text []byte) error {
+	s := strings.TrimSpace(string(text))
+	for value, name := range showCommandNames {
+		if strings.EqualFold(s, name) {
+			*c = value
+			return nil
+		}
+	}
+	if value, ok := showCommandAliases[strings.ToLower(s)]; ok {
+		*c = value
+		return nil
+	}
+	const prefix = "ShowCommand("
+	number := s
+	if len(s) > len(prefix) && strings.EqualFold(s[:len(prefix)], prefix) && strings.HasSuffix(s, ")") {
+		number = s[len(prefix) : len(s)-1]
+	}
+	if n, err := strconv.ParseUint(number, 0, 32); err == nil {
+		*c = ShowCommand(n)
+		return nil
+	}
+	return fmt.Errorf("invalid show command \"%s\"", s)
+}
@@ -0,0 +1,80 @@
+package shelllink_test
+
+import (
+	"testing"
+
+	"github.com/gentlemanautomaton/winshell/shelllink"
+)
+
+func TestShowCommandEffective(t *testing.T) {
+	tests := []struct {
+		Value shelllink.ShowCommand
+		Want  shelllink.ShowCommand
+	}{
+		{0, shelllink.ShowNormal},
+		{1, shelllink.ShowNormal},
+		{2, shelllink.ShowNormal},
+		{3, shelllink.ShowMaximized},
+		{5, shelllink.ShowNormal},
+		{6, shelllink.ShowNormal},
+		{7, shelllink.ShowMinNoActive},
+		{11, shelllink.ShowNormal},
+		{0xFFFF, shelllink.ShowNormal},
+	}
+
+	for _, test := range tests {
+		if got := test.Value.Effective(); got != test.Want {
+			t.Errorf("%d: got %s, want %s", uint32(test.Value), got, test.Want)
+		}
+	}
+}
+
+func TestShowCommandText(t *testing.T) {
+	tests := []struct {
+		Text string
+		Want shelllink.ShowCommand
+	}{
+		{"Maximized", shelllink.ShowMaximized},
+		{"minnoactive", shelllink.ShowMinNoActive},
+		{"SW_SHOWNORMAL", shelllink.ShowNormal},
+		{"SW_SHOWMINNOACTIVE", shelllink.ShowMinNoActive},
+		{"3", shelllink.ShowMaximized},
+		{"11", 11},
+		{"ShowCommand(11)", 11},
+		{"showcommand(0x10)", 16},
+	}
+
+	for _, test := range tests {
+		var c shelllink.ShowCommand
+		if err := c.UnmarshalText([]byte(test.Text)); err != nil {
+			t.Errorf("%q: %v", test.Text, err)
+			continue
+		}
+		if c != test.Want {
+			t.Errorf("%q: got %s, want %s", test.Text, c, test.Want)
+		}
+	}
+
+	for _, value := range []shelllink.ShowCommand{shelllink.ShowNormal, shelllink.ShowMinNoActive, 0, 2, 11} {
+		text, err := value.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(text) != value.String() {
+			t.Errorf("%d: got %q, want %q", uint32(value), text, value.String())
+		}
+		var c shelllink.ShowCommand
+		if err := c.UnmarshalText(text); err != nil {
+			t.Errorf("%q: %v", text, err)
+		} else if c != value {
+			t.Errorf("%q: round trip produced %d", text, uint32(c))
+		}
+	}
+
+	for _, text := range []string{"Fullscreen", "SW_MINIMIZE", "minimized", "ShowCommand(", "ShowCommand(x)"} {
+		var c shelllink.ShowCommand
+		if err := c.UnmarshalText([]byte(text)); err == nil {
+			t.Errorf("%q: unmarshaling an unrecognized show command did not fail", text)
+		}
+	}
+}
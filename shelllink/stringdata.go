@@ -0,0 +1,119 @@
+package shelllink
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/gentlemanautomaton/winshell/internal/utf16le"
+)
+
+// StringData holds the optional strings of a shell link.
+//
+// The strings are stored as UTF-16 when the IsUnicode link flag is set.
+// Otherwise they are stored in the ANSI code page of the system that
+// created the link, which must be known to decode them correctly.
+//
+// https://docs.microsoft.com/en-us/openspecs/windows_protocols/ms-shllink/17b69472-0f34-4bcf-b290-eccdb8de224b
+type StringData struct {
+	Name         string
+	RelativePath string
+	WorkingDir   string
+	Arguments    string
+	IconLocation string
+}
+
+// fields returns the string data fields paired with the link flags that
+// indicate their presence, in the order they are stored.
+func (sd *StringData) fields() []stringField {
+	return []stringField{
+		{HasName, &sd.Name},
+		{HasRelativePath, &sd.RelativePath},
+		{HasWorkingDir, &sd.WorkingDir},
+		{HasArguments, &sd.Arguments},
+		{HasIconLocation, &sd.IconLocation},
+	}
+}
+
+type stringField struct {
+	flag  LinkFlags
+	value *string
+}
+
+// unmarshal parses the strings present in flags from data. Strings that
+// are not stored as Unicode are decoded with the given code page. It
+// returns the number of bytes consumed.
+func (sd *StringData) unmarshal(data []byte, flags LinkFlags, cp CodePage) (n int, err error) {
+	unicode := flags&IsUnicode != 0
+	for _, field := range sd.fields() {
+		if flags&field.flag == 0 {
+			continue
+		}
+
+		if len(data)-n < 2 {
+			return n, fmt.Errorf("the shell link string data is truncated at offset %d", n)
+		}
+		count := int(binary.LittleEndian.Uint16(data[n : n+2]))
+		n += 2
+
+		size := count
+		if unicode {
+			size *= 2
+		}
+		if len(data)-n < size {
+			return n, fmt.Errorf("the shell link string data declares a %d byte string at offset %d, but only %d bytes remain", size, n, len(data)-n)
+		}
+
+		if unicode {
+			*field.value = utf16le.Decode(data[n : n+size])
+		} else if *field.value, err = cp.Decode(data[n : n+size]); err != nil {
+			return n, err
+		}
+		n += size
+	}
+	return n, nil
+}
+
+// flags returns the link flags that indicate the presence of each
+// non-empty string.
+func (sd StringData) flags() (flags LinkFlags) {
+	for _, field := range sd.fields() {
+		if *field.value != "" {
+			flags |= field.flag
+		}
+	}
+	return flags
+}
+
+// marshal returns the binary representation of the non-empty strings.
+// If unicode is true the strings are encoded as UTF-16, otherwise they are
+// encoded with the given code page.
+func (sd StringData) marshal(unicode bool, cp CodePage) ([]byte, error) {
+	var data []byte
+	for _, field := range sd.fields() {
+		if *field.value == "" {
+			continue
+		}
+
+		var (
+			encoded []byte
+			count   int
+		)
+		if unicode {
+			encoded = utf16le.Encode(*field.value)
+			count = len(encoded) / 2
+		} else {
+			var err error
+			if encoded, err = cp.Encode(*field.value); err != nil {
+				return nil, err
+			}
+			count = len(encoded)
+		}
+		if count > 65535 {
+			return nil, fmt.Errorf("the shell link string data holds a string of %d characters, which exceeds the limit of 65535", count)
+		}
+
+		data = binary.LittleEndian.AppendUint16(data, uint16(count))
+		data = append(data, encoded...)
+	}
+	return data, nil
+}
@@ -0,0 +1,155 @@
+package shelllink
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/gentlemanautomaton/winshell/internal/guid"
+	"github.com/google/uuid"
+)
+
+// trackerDataSize is the number of bytes in a TrackerDataBlock, excluding
+// its size and signature.
+const trackerDataSize = 0x60 - blockHeaderSize
+
+// trackerMachineIDSize is the size of the NetBIOS machine ID field.
+const trackerMachineIDSize = 16
+
+// TrackerData holds data used by the distributed link tracking service to
+// find a link's target after it has moved. It corresponds to a
+// TrackerDataBlock.
+//
+// MachineID is the NetBIOS name of the machine on which the target was
+// last known to reside. Droid identifies the target's current volume and
+// file, and BirthDroid identifies the volume and file on which it was
+// created.
+type TrackerData struct {
+	MachineID  string
+	Droid      Droid
+	BirthDroid Droid
+
+	version   uint32
+	machineID []byte
+}
+
+// Droid is a pair of identifiers used by the distributed link tracking
+// service. VolumeID identifies an NTFS volume and ObjectID identifies a
+// file on that volume.
+//
+// Object identifiers are usually version 1 UUIDs, which embed the time
+// at which they were created and the MAC address of the machine that
+// created them.
+type Droid struct {
+	VolumeID uuid.UUID
+	ObjectID uuid.UUID
+}
+
+// Signature returns the signature of the block.
+func (t TrackerData) Signature() Signature {
+	return TrackerDataBlock
+}
+
+// UnmarshalBinary parses tracker data from data, which excludes the
+// block's size and signature. The machine ID is decoded as Windows-1252.
+func (t *TrackerData) UnmarshalBinary(data []byte) error {
+	return t.unmarshal(data, CodePageDefault)
+}
+
+// unmarshal parses tracker data from data, decoding the machine ID with
+// cp.
+func (t *TrackerData) unmarshal(data []byte, cp CodePage) error {
+	if err := checkBlockSize(TrackerDataBlock, data, trackerDataSize); err != nil {
+		return err
+	}
+	if length := binary.LittleEndian.Uint32(data[0:4]); length != trackerDataSize {
+		return fmt.Errorf("the %s declares a length of %d bytes instead of %d", TrackerDataBlock, length, trackerDataSize)
+	}
+
+	machineID := data[8 : 8+trackerMachineIDSize]
+	name, err := decodeFixedANSI(machineID, cp)
+	if err != nil {
+		return err
+	}
+
+	*t = TrackerData{
+		MachineID:  name,
+		Droid:      decodeDroid(data[24:56]),
+		BirthDroid: decodeDroid(data[56:88]),
+		version:    binary.LittleEndian.Uint32(data[4:8]),
+		machineID:  bytes.Clone(machineID),
+	}
+	return nil
+}
+
+// MarshalBinary returns the binary representation of the tracker data,
+// excluding the block's size and signature. The machine ID is encoded as
+// Windows-1252 and is limited to 15 bytes.
+func (t TrackerData) MarshalBinary() ([]byte, error) {
+	return t.marshal(CodePageDefault)
+}
+
+// marshal returns the binary representation of the tracker data,
+// encoding the machine ID with cp.
+func (t TrackerData) marshal(cp CodePage) ([]byte, error) {
+	data := make([]byte, trackerDataSize)
+	binary.LittleEndian.PutUint32(data[0:4], trackerDataSize)
+	binary.LittleEndian.PutUint32(data[4:8], t.version)
+
+	machineID := data[8 : 8+trackerMachineIDSize]
+	if original, err := decodeFixedANSI(t.machineID, cp); t.machineID != nil && err == nil && original == t.MachineID {
+		copy(machineID, t.machineID)
+	} else if err := putFixedANSI(machineID, t.MachineID, cp); err != nil {
+		return nil, err
+	}
+
+	t.Droid.put(data[24:56])
+	t.BirthDroid.put(data[56:88])
+
+	return data, nil
+}
+
+// decodeDroid decodes a droid from the 32 bytes of data.
+func decodeDroid(data []byte) Droid {
+	return Droid{
+		VolumeID: guid.Decode(data[0:16]),
+		ObjectID: guid.Decode(data[16:32]),
+	}
+}
+
+// put writes the droid to the 32 bytes of data.
+func (d Droid) put(data []byte) {
+	guid.Put(data[0:16], d.VolumeID)
+	guid.Put(data[16:32], d.ObjectID)
+}
+
+// Time returns the time at which the object identifier was created. It
+// returns false if the object identifier is not a version 1 UUID.
+func (d Droid) Time() (time.Time, bool) {
+	if d.ObjectID.Version() != 1 {
+		return time.Time{}, false
+	}
+	sec, nsec := d.ObjectID.Time().UnixTime()
+	return time.Unix(sec, nsec).UTC(), true
+}
+
+// ClockSequence returns the clock sequence of the object identifier. It
+// returns false if the object identifier is not a version 1 UUID.
+func (d Droid) ClockSequence() (int, bool) {
+	if d.ObjectID.Version() != 1 {
+		return 0, false
+	}
+	return d.ObjectID.ClockSequence(), true
+}
+
+// MAC returns the MAC address embedded in the object identifier, which
+// is normally that of the machine that created it. It returns false if
+// the object identifier is not a version 1 UUID.
+func (d Droid) MAC() (net.HardwareAddr, bool) {
+	if d.ObjectID.Version() != 1 {
+		return nil, false
+	}
+	return net.HardwareAddr(d.ObjectID.NodeID()), true
+}
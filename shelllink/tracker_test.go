@@ -0,0 +1,62 @@
+package shelllink_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gentlemanautomaton/winshell/shelllink"
+	"github.com/google/uuid"
+)
+
+func TestTrackerData(t *testing.T) {
+	volume := uuid.MustParse("94c6f8a1-3e2b-4c4d-9a4e-7c1f2b3d4e5f")
+	object := uuid.MustParse("ec46cd70-dbde-11e1-8b6b-000c29a1b2c3")
+
+	source := &shelllink.TrackerData{
+		MachineID:  "workstation-7",
+		Droid:      shelllink.Droid{VolumeID: volume, ObjectID: object},
+		BirthDroid: shelllink.Droid{VolumeID: volume, ObjectID: object},
+	}
+
+	data, err := source.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 0x58 {
+		t.Fatalf("got %d bytes, want %d", len(data), 0x58)
+	}
+
+	var tracker shelllink.TrackerData
+	if err := tracker.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if tracker.MachineID != source.MachineID {
+		t.Errorf("machine ID: got %q, want %q", tracker.MachineID, source.MachineID)
+	}
+	if tracker.Droid != source.Droid || tracker.BirthDroid != source.BirthDroid {
+		t.Errorf("droids: got %+v and %+v", tracker.Droid, tracker.BirthDroid)
+	}
+
+	created, ok := tracker.BirthDroid.Time()
+	if !ok {
+		t.Fatal("the birth object ID was not recognized as a version 1 UUID")
+	}
+	if want := time.Date(2012, 8, 1, 13, 43, 49, 0, time.UTC); created.Truncate(time.Second) != want {
+		t.Errorf("time: got %v, want %v", created, want)
+	}
+	if seq, _ := tracker.BirthDroid.ClockSequence(); seq != 0x0b6b {
+		t.Errorf("clock sequence: got %#x, want %#x", seq, 0x0b6b)
+	}
+	if mac, _ := tracker.BirthDroid.MAC(); mac.String() != "00:0c:29:a1:b2:c3" {
+		t.Errorf("MAC: got %s", mac)
+	}
+
+	if _, ok := (shelllink.Droid{ObjectID: volume}).Time(); ok {
+		t.Error("a version 4 object ID was treated as a version 1 UUID")
+	}
+
+	long := shelllink.TrackerData{MachineID: "a-machine-name-too-long"}
+	if _, err := long.MarshalBinary(); err == nil {
+		t.Error("marshaling a machine ID longer than 15 characters did not fail")
+	}
+}
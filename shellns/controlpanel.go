@@ -0,0 +1,45 @@
+package shellns
+
+import (
+	"github.com/gentlemanautomaton/winshell/internal/guid"
+	"github.com/google/uuid"
+)
+
+// ControlPanelItem is an item that identifies a control panel applet by
+// its class identifier.
+type ControlPanelItem struct {
+	// ClassID is the class identifier of the control panel applet.
+	ClassID uuid.UUID
+
+	// Extra holds any bytes that follow the class identifier.
+	Extra []byte
+
+	unknown [11]byte
+}
+
+// ClassType returns the class type indicator of the item.
+func (c ControlPanelItem) ClassType() byte {
+	return controlPanelType
+}
+
+// UnmarshalBinary decodes a control panel item from data.
+func (c *ControlPanelItem) UnmarshalBinary(data []byte) error {
+	if err := checkItem("control panel", data, 28); err != nil {
+		return err
+	}
+	*c = ControlPanelItem{
+		ClassID: guid.Decode(data[12:28]),
+		Extra:   clone(data[28:]),
+	}
+	copy(c.unknown[:], data[1:12])
+	return nil
+}
+
+// MarshalBinary returns the binary representation of the item.
+func (c ControlPanelItem) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 28, 28+len(c.Extra))
+	data[0] = controlPanelType
+	copy(data[1:12], c.unknown[:])
+	guid.Put(data[12:28], c.ClassID)
+	return append(data, c.Extra...), nil
+}
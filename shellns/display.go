@@ -0,0 +1,173 @@
+package shellns
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/gentlemanautomaton/winshell/propkey"
+	"github.com/gentlemanautomaton/winshell/propstore"
+	"github.com/gentlemanautomaton/winshell/shellclass"
+	"github.com/gentlemanautomaton/winshell/winpath"
+	"github.com/google/uuid"
+)
+
+var (
+	// networkPlaces is the class identifier of the network root folder
+	// (CLSID_NetworkPlaces).
+	//
+	//	{208D2C60-3AEA-1069-A2D7-08002B30309D}
+	networkPlaces = uuid.UUID{0x20, 0x8D, 0x2C, 0x60, 0x3A, 0xEA, 0x10, 0x69, 0xA2, 0xD7, 0x08, 0x00, 0x2B, 0x30, 0x30, 0x9D}
+
+	// networkExplorer is the class identifier of the network root folder
+	// on Windows Vista and later (CLSID_NetworkExplorerFolder).
+	//
+	//	{F02C1A0D-BE21-4350-88B0-7367FC96EF3C}
+	networkExplorer = uuid.UUID{0xF0, 0x2C, 0x1A, 0x0D, 0xBE, 0x21, 0x43, 0x50, 0x88, 0xB0, 0x73, 0x67, 0xFC, 0x96, 0xEF, 0x3C}
+
+	// appsFolder is the class identifier of the applications folder
+	// (CLSID_AppsFolder).
+	//
+	//	{4234D49B-0245-4DF3-B780-3893943456E1}
+	appsFolder = uuid.UUID{0x42, 0x34, 0xD4, 0x9B, 0x02, 0x45, 0x4D, 0xF3, 0xB7, 0x80, 0x38, 0x93, 0x94, 0x34, 0x56, 0xE1}
+)
+
+// ParsingName returns a best-effort parsing name for the list, in the
+// form that Explorer would display it. Examples include:
+//
+//	C:\Users\alice\report.docx
+//	\\server\share\report.docx
+//	::{20D04FE0-3AEA-1069-A2D8-08002B30309D}
+//	shell:AppsFolder\Microsoft.WindowsCalculator_8wekyb3d8bbwe!App
+//
+// Items that cannot be decoded or represented are written as their class
+// type in angle brackets, such as <0x74>. An empty list, which represents
+// the desktop, returns an empty string.
+func (list List) ParsingName() string {
+	var (
+		name   string
+		inApps bool
+	)
+
+	// join appends an element to the name with a separator
+	join := func(element string) {
+		switch {
+		case name == "":
+			name = element
+		case strings.HasSuffix(name, `\`):
+			name += element
+		default:
+			name += `\` + element
+		}
+	}
+
+	for i, item := range list {
+		typed, err := item.Decode()
+		if err != nil {
+			join(fmt.Sprintf("<0x%02X>", item.ClassType()))
+			continue
+		}
+
+		switch typed := typed.(type) {
+		case *RootFolderItem:
+			inApps = false
+			switch {
+			case typed.ClassID == shellclass.MyComputer && i+1 < len(list):
+				// Drives are displayed without the My Computer prefix
+			case (typed.ClassID == networkPlaces || typed.ClassID == networkExplorer) && i+1 < len(list):
+				// Network locations are displayed without a prefix
+			case typed.ClassID == appsFolder:
+				join("shell:AppsFolder")
+				inApps = true
+			default:
+				join(classPath(typed.ClassID))
+			}
+		case *VolumeItem:
+			if typed.Type == volumeShellFolder {
+				join(classPath(typed.ClassID))
+			} else {
+				join(typed.Name)
+			}
+		case *FileEntryItem:
+			join(typed.Name())
+		case *NetworkLocationItem:
+			// Network locations hold their complete UNC path
+			if name == "" || winpath.Classify(name) == winpath.UNC {
+				name = typed.Location
+			} else {
+				join(typed.Location)
+			}
+		case *URIItem:
+			// URIs are absolute
+			name = typed.URI
+		case *ControlPanelItem:
+			join(classPath(typed.ClassID))
+		default:
+			if inApps {
+				if id, ok := appUserModelID(item); ok {
+					join(id)
+					continue
+				}
+			}
+			join(fmt.Sprintf("<0x%02X>", item.ClassType()))
+		}
+	}
+
+	return name
+}
+
+// classPath returns the parsing name of a shell folder with the given
+// class identifier.
+func classPath(id uuid.UUID) string {
+	return "::{" + strings.ToUpper(id.String()) + "}"
+}
+
+// appUserModelID searches item for serialized property storage that
+// holds a System.AppUserModel.ID property, and returns its value.
+func appUserModelID(item Item) (id string, ok bool) {
+	// storageVersion is the version that follows the size of each
+	// serialized property storage structure
+	storageVersion := []byte("1SPS")
+
+	for offset := 0; ; {
+		i := bytes.Index(item[offset:], storageVersion)
+		if i < 0 {
+			return "", false
+		}
+		start := offset + i - 4
+		offset += i + len(storageVersion)
+		if start < 0 {
+			continue
+		}
+
+		value, ok := readPropertyStore(item[start:]).Get(propkey.AppUserModelID)
+		if !ok {
+			continue
+		}
+		if id, ok := value.AsString(); ok {
+			return id, true
+		}
+	}
+}
+
+// readPropertyStore parses the serialized property storage structures at
+// the start of data. It stops at a terminal, at a structure that cannot
+// be parsed or at the end of data, and returns the structures parsed
+// before that point.
+func readPropertyStore(data []byte) propstore.Store {
+	var store propstore.Store
+	for len(data) >= 4 {
+		size := int(binary.LittleEndian.Uint32(data[0:4]))
+		if size == 0 || size > len(data) {
+			break
+		}
+		var set propstore.Set
+		if err := set.UnmarshalBinary(data[:size]); err != nil {
+			break
+		}
+		store = append(store, set)
+		data = data[size:]
+	}
+	return store
+}
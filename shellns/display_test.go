@@ -0,0 +1,108 @@
+package shellns_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/gentlemanautomaton/winshell/internal/guid"
+	"github.com/gentlemanautomaton/winshell/shellclass"
+	"github.com/gentlemanautomaton/winshell/shellns"
+	"github.com/google/uuid"
+)
+
+func marshalItems(t *testing.T, items ...shellns.TypedItem) shellns.List {
+	t.Helper()
+	var list shellns.List
+	for _, item := range items {
+		data, err := item.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		list = append(list, data)
+	}
+	return list
+}
+
+// appItem returns an item that holds serialized property storage with a
+// System.AppUserModel.ID property, similar to those found beneath the
+// applications folder.
+func appItem(id string) shellns.Item {
+	var value bytes.Buffer
+	le := func(v interface{}) { binary.Write(&value, binary.LittleEndian, v) }
+	name := append(utf16.Encode([]rune(id)), 0)
+	le(uint32(0))
+	le(uint32(5))
+	value.WriteByte(0)
+	le(uint32(0x1F))
+	le(uint32(len(name)))
+	le(name)
+	for value.Len()%4 != 1 {
+		value.WriteByte(0)
+	}
+	data := value.Bytes()
+	binary.LittleEndian.PutUint32(data[0:4], uint32(len(data)))
+
+	var storage bytes.Buffer
+	binary.Write(&storage, binary.LittleEndian, uint32(4+4+16+len(data)+4))
+	storage.WriteString("1SPS")
+	storage.Write(guid.Bytes(uuid.MustParse("9F4C2855-9F79-4B39-A8D0-E1D42DE1D5F3")))
+	storage.Write(data)
+	storage.Write([]byte{0, 0, 0, 0})
+
+	return append(shellns.Item{0x00, 0x00, 0x00, 0x00}, storage.Bytes()...)
+}
+
+func TestListParsingName(t *testing.T) {
+	desktop, err := shellns.FromPath(`C:\Users\alice\Desktop\`, shellns.PathOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	drive, err := shellns.FromPath(`D:\`, shellns.PathOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		network      = uuid.MustParse("F02C1A0D-BE21-4350-88B0-7367FC96EF3C")
+		apps         = uuid.MustParse("4234D49B-0245-4DF3-B780-3893943456E1")
+		controlPanel = uuid.MustParse("26EE0668-A00A-44D7-9371-BEB064C98683")
+		printers     = uuid.MustParse("2227A280-3AEA-1069-A2DE-08002B30309D")
+	)
+
+	tests := []struct {
+		name string
+		list shellns.List
+		want string
+	}{
+		{"Empty", shellns.List{}, ""},
+		{"Drive", drive, `D:\`},
+		{"Directory", desktop, `C:\Users\alice\Desktop`},
+		{"MyComputer", marshalItems(t, shellns.RootFolderItem{SortIndex: 0x50, ClassID: shellclass.MyComputer}), `::{20D04FE0-3AEA-1069-A2D8-08002B30309D}`},
+		{"Network", marshalItems(t,
+			shellns.RootFolderItem{SortIndex: 0x58, ClassID: network},
+			shellns.NetworkLocationItem{Type: 0x42, Location: `\\SRV`},
+			shellns.NetworkLocationItem{Type: 0x43, Location: `\\SRV\share`},
+			shellns.FileEntryItem{Type: 0x31, ShortName: "docs"},
+		), `\\SRV\share\docs`},
+		{"ControlPanel", marshalItems(t,
+			shellns.RootFolderItem{SortIndex: 0x80, ClassID: controlPanel},
+			shellns.ControlPanelItem{ClassID: printers},
+		), `::{26EE0668-A00A-44D7-9371-BEB064C98683}\::{2227A280-3AEA-1069-A2DE-08002B30309D}`},
+		{"URI", marshalItems(t, shellns.URIItem{Flags: 0x80, URI: "ftp://example.com/pub"}), "ftp://example.com/pub"},
+		{"AppsFolder", append(
+			marshalItems(t, shellns.RootFolderItem{SortIndex: 0x48, ClassID: apps}),
+			appItem("Microsoft.WindowsCalculator_8wekyb3d8bbwe!App"),
+		), `shell:AppsFolder\Microsoft.WindowsCalculator_8wekyb3d8bbwe!App`},
+		{"Unknown", append(drive, shellns.Item{0x74, 0x1A}), `D:\<0x74>`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.list.ParsingName(); got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
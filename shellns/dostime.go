@@ -0,0 +1,36 @@
+package shellns
+
+import "time"
+
+// dosToTime converts an MS-DOS date and time to a time.Time. Since MS-DOS
+// timestamps do not include a time zone, the time is returned in UTC
+// with the same wall clock values. A date of zero is converted to the zero
+// time.
+//
+// https://docs.microsoft.com/en-us/windows/win32/api/winbase/nf-winbase-dosdatetimetofiletime
+func dosToTime(date, tod uint16) time.Time {
+	if date == 0 {
+		return time.Time{}
+	}
+	return time.Date(
+		1980+int(date>>9),
+		time.Month(date>>5&0x0F),
+		int(date&0x1F),
+		int(tod>>11),
+		int(tod>>5&0x3F),
+		int(tod&0x1F)*2,
+		0,
+		time.UTC)
+}
+
+// timeToDOS converts t to an MS-DOS date and time, using the wall clock
+// values of t. Times outside of the range representable by MS-DOS
+// timestamps, including the zero time, are converted to zero.
+func timeToDOS(t time.Time) (date, tod uint16) {
+	if t.Year() < 1980 || t.Year() > 2107 {
+		return 0, 0
+	}
+	date = uint16(t.Year()-1980)<<9 | uint16(t.Month())<<5 | uint16(t.Day())
+	tod = uint16(t.Hour())<<11 | uint16(t.Minute())<<5 | uint16(t.Second()/2)
+	return date, tod
+}
@@ -0,0 +1,47 @@
+package shellns
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrTruncated is returned when an item ID list ends before its
+	// declared size or before its terminal.
+	ErrTruncated = errors.New("the shell namespace item ID list is truncated")
+
+	// ErrMissingTerminal is returned when an item ID list does not end
+	// with a terminal.
+	ErrMissingTerminal = errors.New("the shell namespace item ID list is missing its terminal")
+
+	// ErrTrailingData is returned when an item ID list holds data beyond
+	// its terminal.
+	ErrTrailingData = errors.New("the shell namespace item ID list holds data beyond its terminal")
+
+	// ErrInvalidItemSize is returned when an item declares a size that is
+	// too small to hold its own size field, or that extends beyond the
+	// end of the list.
+	ErrInvalidItemSize = errors.New("the shell namespace item declares an invalid size")
+)
+
+// ItemSizeError reports an item within an item ID list that declares an
+// invalid size.
+type ItemSizeError struct {
+	Index     int // Index of the item within the list
+	Offset    int // Offset of the item within the list, excluding the list header
+	Size      int // Size declared by the item, including its size field
+	Remaining int // Number of bytes remaining in the list at the item's offset
+}
+
+// Error returns a description of the error.
+func (e ItemSizeError) Error() string {
+	if e.Size < 2 {
+		return fmt.Sprintf("shell namespace item %d at offset %d declares a size of %d bytes, which is too small to hold its size", e.Index, e.Offset, e.Size)
+	}
+	return fmt.Sprintf("shell namespace item %d at offset %d declares a size of %d bytes, but only %d bytes remain in the list", e.Index, e.Offset, e.Size, e.Remaining)
+}
+
+// Unwrap returns ErrInvalidItemSize.
+func (e ItemSizeError) Unwrap() error {
+	return ErrInvalidItemSize
+}
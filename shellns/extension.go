@@ -0,0 +1,168 @@
+package shellns
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"fmt"
+	"sync"
+)
+
+// ExtensionSignature identifies the type of an extension block within a
+// shell item. Extension block signatures have the form 0xBEEF00XX.
+type ExtensionSignature uint32
+
+// Extension block signatures.
+const (
+	ShellFolderExtensionBlock   ExtensionSignature = 0xBEEF0003
+	FileEntryExtensionBlock     ExtensionSignature = 0xBEEF0004
+	TimestampExtensionBlock     ExtensionSignature = 0xBEEF0026
+	PropertyStoreExtensionBlock ExtensionSignature = 0xBEEF0027
+)
+
+// extensionHeaderSize is the number of bytes in the size, version and
+// signature that begin each extension block.
+const extensionHeaderSize = 8
+
+// ExtensionBlock is an extension block within a shell item.
+//
+// The binary representation of an extension block is the entire block,
+// including its 16 bit size, 16 bit version and 32 bit signature.
+type ExtensionBlock interface {
+	Signature() ExtensionSignature
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+}
+
+var (
+	extensionMutex sync.RWMutex
+	extensionTypes = map[ExtensionSignature]func() ExtensionBlock{
+		ShellFolderExtensionBlock:   func() ExtensionBlock { return new(ShellFolderExtension) },
+		FileEntryExtensionBlock:     func() ExtensionBlock { return new(FileEntryExtension) },
+		TimestampExtensionBlock:     func() ExtensionBlock { return new(TimestampExtension) },
+		PropertyStoreExtensionBlock: func() ExtensionBlock { return new(PropertyStoreExtension) },
+	}
+)
+
+// RegisterExtension registers a function that returns a new, empty
+// extension block for the given signature. It replaces any existing
+// registration for the signature.
+//
+// Extension blocks with a registered signature are decoded by calling
+// UnmarshalBinary on the value returned by fn.
+func RegisterExtension(sig ExtensionSignature, fn func() ExtensionBlock) {
+	extensionMutex.Lock()
+	defer extensionMutex.Unlock()
+	extensionTypes[sig] = fn
+}
+
+// decodeExtension returns a typed extension block for data, which must
+// hold exactly one extension block.
+//
+// If the block's signature is not registered, or if the typed block does
+// not reproduce data exactly when marshaled, the block is returned as a
+// RawExtension so that it can be marshaled without loss.
+func decodeExtension(data []byte) ExtensionBlock {
+	raw := new(RawExtension)
+	raw.UnmarshalBinary(data)
+
+	extensionMutex.RLock()
+	fn, ok := extensionTypes[raw.BlockSignature]
+	extensionMutex.RUnlock()
+	if !ok {
+		return raw
+	}
+
+	typed := fn()
+	if err := typed.UnmarshalBinary(data); err != nil {
+		return raw
+	}
+	if encoded, err := typed.MarshalBinary(); err != nil || !bytes.Equal(encoded, data) {
+		return raw
+	}
+	return typed
+}
+
+// parseExtensions parses a sequence of extension blocks from data. It
+// stops at the first sequence of bytes that does not form a valid block
+// and returns those bytes as rest.
+func parseExtensions(data []byte) (blocks []ExtensionBlock, rest []byte) {
+	for len(data) >= extensionHeaderSize {
+		size := int(binary.LittleEndian.Uint16(data[0:2]))
+		sig := binary.LittleEndian.Uint32(data[4:8])
+		if size < extensionHeaderSize || size > len(data) || sig&0xFFFF0000 != 0xBEEF0000 {
+			break
+		}
+		blocks = append(blocks, decodeExtension(data[:size]))
+		data = data[size:]
+	}
+	return blocks, data
+}
+
+// parseExtensionHeader verifies that data holds an extension block with
+// the given signature and a size that matches the length of data. It
+// returns the version of the block.
+func parseExtensionHeader(data []byte, sig ExtensionSignature, min int) (version uint16, err error) {
+	if len(data) < min {
+		return 0, fmt.Errorf("the %#x extension block requires at least %d bytes, but only %d bytes are present", uint32(sig), min, len(data))
+	}
+	if size := int(binary.LittleEndian.Uint16(data[0:2])); size != len(data) {
+		return 0, fmt.Errorf("the %#x extension block declares a size of %d bytes, but %d bytes are present", uint32(sig), size, len(data))
+	}
+	if got := ExtensionSignature(binary.LittleEndian.Uint32(data[4:8])); got != sig {
+		return 0, fmt.Errorf("the extension block has a signature of %#x instead of %#x", uint32(got), uint32(sig))
+	}
+	return binary.LittleEndian.Uint16(data[2:4]), nil
+}
+
+// appendExtensionHeader returns a slice that begins with an extension
+// block header for the given signature and version. The size must be
+// filled in by finishExtension.
+func appendExtensionHeader(sig ExtensionSignature, version uint16) []byte {
+	data := make([]byte, extensionHeaderSize)
+	binary.LittleEndian.PutUint16(data[2:4], version)
+	binary.LittleEndian.PutUint32(data[4:8], uint32(sig))
+	return data
+}
+
+// finishExtension writes the size of an extension block to its header.
+func finishExtension(data []byte) ([]byte, error) {
+	if len(data) > 65535 {
+		return nil, fmt.Errorf("the extension block requires %d bytes, which exceeds the limit of 65535", len(data))
+	}
+	binary.LittleEndian.PutUint16(data[0:2], uint16(len(data)))
+	return data, nil
+}
+
+// RawExtension is an extension block held in its binary form. It is used
+// for blocks with unrecognized signatures.
+type RawExtension struct {
+	BlockSignature ExtensionSignature
+	Version        uint16
+
+	// Data holds the bytes of the block that follow its signature.
+	Data []byte
+}
+
+// Signature returns the signature of the block.
+func (r RawExtension) Signature() ExtensionSignature {
+	return r.BlockSignature
+}
+
+// UnmarshalBinary decodes an extension block from data.
+func (r *RawExtension) UnmarshalBinary(data []byte) error {
+	if len(data) < extensionHeaderSize {
+		return fmt.Errorf("the extension block requires at least %d bytes, but only %d bytes are present", extensionHeaderSize, len(data))
+	}
+	*r = RawExtension{
+		BlockSignature: ExtensionSignature(binary.LittleEndian.Uint32(data[4:8])),
+		Version:        binary.LittleEndian.Uint16(data[2:4]),
+		Data:           clone(data[extensionHeaderSize:]),
+	}
+	return nil
+}
+
+// MarshalBinary returns the binary representation of the block.
+func (r RawExtension) MarshalBinary() ([]byte, error) {
+	return finishExtension(append(appendExtensionHeader(r.BlockSignature, r.Version), r.Data...))
+}
@@ -0,0 +1,198 @@
+package shellns_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	"github.com/gentlemanautomaton/winshell/shellns"
+)
+
+// reportItem returns a file entry item as written by Windows 8 and later,
+// with a file entry extension block, a timestamp extension block and an
+// extension block with an unrecognized signature.
+func reportItem() shellns.Item {
+	var buf bytes.Buffer
+	le := func(v interface{}) { binary.Write(&buf, binary.LittleEndian, v) }
+
+	buf.Write([]byte{0x32, 0x00})
+	le(uint32(0x1000))
+	le([]uint16{0x4F38, 0x9AC5})
+	le(uint16(0x0020))
+	buf.WriteString("REPORT~1.DOC\x00\x00")
+
+	name := append(utf16.Encode([]rune("report.docx")), 0)
+	le(uint16(46 + len(name)*2 + 2))
+	le(uint16(9))
+	le(uint32(0xBEEF0004))
+	le([]uint16{0x4F38, 0x9AC5, 0x4F38, 0x9AC5})
+	le(uint16(0x2E))
+	le(uint16(0))
+	le(uint64(5<<48 | 0x1234))
+	buf.Write(make([]byte, 8+2+4+4))
+	le(name)
+	le(uint16(0x1C))
+
+	le(uint16(12))
+	le(uint16(1))
+	le(uint32(0xBEEF0099))
+	le(uint32(0xCAFEF00D))
+
+	le(uint16(36))
+	le(uint16(1))
+	le(uint32(0xBEEF0026))
+	le(uint32(0x11))
+	le([]uint64{0x01D5730D5C715D00, 0x01D5730D5C715D00, 0x01D5730D5C715D00})
+
+	return buf.Bytes()
+}
+
+func TestFileEntryExtensions(t *testing.T) {
+	item := reportItem()
+
+	typed, err := item.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry := typed.(*shellns.FileEntryItem)
+
+	if got := len(entry.Extensions); got != 3 {
+		t.Fatalf("got %d extension blocks, want 3", got)
+	}
+
+	ext := entry.FileEntryExtension()
+	if ext == nil {
+		t.Fatal("the file entry extension block was not decoded")
+	}
+	if got, want := entry.Name(), "report.docx"; got != want {
+		t.Errorf("name: got %q, want %q", got, want)
+	}
+	if ext.FileReference.Entry() != 0x1234 || ext.FileReference.Sequence() != 5 {
+		t.Errorf("file reference: got entry %#x sequence %d", ext.FileReference.Entry(), ext.FileReference.Sequence())
+	}
+
+	if raw, ok := entry.Extensions[1].(*shellns.RawExtension); !ok || raw.BlockSignature != 0xBEEF0099 {
+		t.Errorf("unknown extension: got %#v", entry.Extensions[1])
+	}
+
+	stamps, ok := entry.Extensions[2].(*shellns.TimestampExtension)
+	if !ok {
+		t.Fatalf("timestamp extension: got %T", entry.Extensions[2])
+	}
+	if want := time.Date(2019, 9, 24, 19, 22, 10, 0, time.UTC); !stamps.Modified.Equal(want) {
+		t.Errorf("timestamp extension modified: got %s, want %s", stamps.Modified, want)
+	}
+
+	data, err := entry.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, item) {
+		t.Errorf("marshaled item differs from the original:\n got %x\nwant %x", data, item)
+	}
+}
+
+func TestFileEntryExtensionOffset(t *testing.T) {
+	typed, err := reportItem().Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry := typed.(*shellns.FileEntryItem)
+	entry.ShortName = "REPORT.DOC"
+
+	data, err := entry.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	typed, err = shellns.Item(data).Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry = typed.(*shellns.FileEntryItem)
+	if _, ok := entry.Extensions[0].(*shellns.FileEntryExtension); !ok {
+		t.Fatalf("got %T after renaming, want a file entry extension block", entry.Extensions[0])
+	}
+
+	// The block begins after the 16 bit item size, 12 bytes of fixed
+	// fields and the padded 11 byte short name
+	block, _ := entry.Extensions[0].MarshalBinary()
+	if got := binary.LittleEndian.Uint16(block[len(block)-2:]); got != 26 {
+		t.Errorf("extension offset: got %d, want 26", got)
+	}
+}
+
+func TestFileEntryExtensionOffsetRaw(t *testing.T) {
+	raw := &shellns.RawExtension{
+		BlockSignature: shellns.FileEntryExtensionBlock,
+		Version:        9,
+		Data:           []byte{0xAA, 0xBB, 0xCC, 0xDD, 0xFF, 0xFF},
+	}
+	entry := shellns.FileEntryItem{
+		Type:       0x32,
+		ShortName:  "A.TXT",
+		Extensions: []shellns.ExtensionBlock{raw},
+	}
+
+	data, err := entry.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The block begins after the 16 bit item size, 12 bytes of fixed
+	// fields and the 6 byte short name
+	if got := binary.LittleEndian.Uint16(data[len(data)-2:]); got != 20 {
+		t.Errorf("extension offset: got %d, want 20", got)
+	}
+	if !bytes.Equal(raw.Data, []byte{0xAA, 0xBB, 0xCC, 0xDD, 0xFF, 0xFF}) {
+		t.Errorf("marshaling modified the raw extension block: %x", raw.Data)
+	}
+}
+
+func TestFileEntryExtensionLocalizedName(t *testing.T) {
+	entry := reportEntry(t, reportItem())
+
+	for _, name := range []string{"Bericht", "Jahresbericht", ""} {
+		entry.FileEntryExtension().LocalizedName = name
+		data, err := entry.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		entry = reportEntry(t, data)
+
+		ext := entry.FileEntryExtension()
+		if ext == nil {
+			t.Fatalf("%q: the file entry extension block was not decoded", name)
+		}
+		if ext.LocalizedName != name {
+			t.Errorf("localized name: got %q, want %q", ext.LocalizedName, name)
+		}
+
+		// The size of the localized name follows the file reference in
+		// version 9 blocks
+		block, _ := ext.MarshalBinary()
+		want := 0
+		if name != "" {
+			want = (len(name) + 1) * 2
+		}
+		if got := binary.LittleEndian.Uint16(block[36:38]); int(got) != want {
+			t.Errorf("%q: localized name size: got %d, want %d", name, got, want)
+		}
+	}
+}
+
+// reportEntry decodes data as a file entry item.
+func reportEntry(t *testing.T, data shellns.Item) *shellns.FileEntryItem {
+	t.Helper()
+	typed, err := data.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry, ok := typed.(*shellns.FileEntryItem)
+	if !ok {
+		t.Fatalf("got %T, want a file entry item", typed)
+	}
+	return entry
+}
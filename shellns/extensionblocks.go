@@ -0,0 +1,120 @@
+package shellns
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/gentlemanautomaton/winshell/internal/filetime"
+	"github.com/gentlemanautomaton/winshell/internal/guid"
+	"github.com/google/uuid"
+)
+
+// ShellFolderExtension is a 0xBEEF0003 extension block. It holds the class
+// identifier of a shell folder.
+type ShellFolderExtension struct {
+	Version uint16
+	ClassID uuid.UUID
+}
+
+// Signature returns the signature of the block.
+func (ext ShellFolderExtension) Signature() ExtensionSignature {
+	return ShellFolderExtensionBlock
+}
+
+// UnmarshalBinary decodes the extension block from data.
+func (ext *ShellFolderExtension) UnmarshalBinary(data []byte) error {
+	version, err := parseExtensionHeader(data, ShellFolderExtensionBlock, 24)
+	if err != nil {
+		return err
+	}
+	*ext = ShellFolderExtension{
+		Version: version,
+		ClassID: guid.Decode(data[8:24]),
+	}
+	return nil
+}
+
+// MarshalBinary returns the binary representation of the block.
+func (ext ShellFolderExtension) MarshalBinary() ([]byte, error) {
+	data := appendExtensionHeader(ShellFolderExtensionBlock, ext.Version)
+	return finishExtension(append(data, guid.Bytes(ext.ClassID)...))
+}
+
+// TimestampExtension is a 0xBEEF0026 extension block. It holds the
+// creation, modification and last access times of a file with the full
+// precision of a FILETIME.
+type TimestampExtension struct {
+	Version  uint16
+	Flags    uint32
+	Created  time.Time
+	Modified time.Time
+	Accessed time.Time
+
+	// Extra holds any bytes that follow the timestamps.
+	Extra []byte
+}
+
+// Signature returns the signature of the block.
+func (ext TimestampExtension) Signature() ExtensionSignature {
+	return TimestampExtensionBlock
+}
+
+// UnmarshalBinary decodes the extension block from data.
+func (ext *TimestampExtension) UnmarshalBinary(data []byte) error {
+	version, err := parseExtensionHeader(data, TimestampExtensionBlock, 36)
+	if err != nil {
+		return err
+	}
+	*ext = TimestampExtension{
+		Version:  version,
+		Flags:    binary.LittleEndian.Uint32(data[8:12]),
+		Created:  filetime.ToTime(binary.LittleEndian.Uint64(data[12:20])),
+		Modified: filetime.ToTime(binary.LittleEndian.Uint64(data[20:28])),
+		Accessed: filetime.ToTime(binary.LittleEndian.Uint64(data[28:36])),
+		Extra:    clone(data[36:]),
+	}
+	return nil
+}
+
+// MarshalBinary returns the binary representation of the block.
+func (ext TimestampExtension) MarshalBinary() ([]byte, error) {
+	data := appendExtensionHeader(TimestampExtensionBlock, ext.Version)
+	data = binary.LittleEndian.AppendUint32(data, ext.Flags)
+	data = binary.LittleEndian.AppendUint64(data, filetime.FromTime(ext.Created))
+	data = binary.LittleEndian.AppendUint64(data, filetime.FromTime(ext.Modified))
+	data = binary.LittleEndian.AppendUint64(data, filetime.FromTime(ext.Accessed))
+	return finishExtension(append(data, ext.Extra...))
+}
+
+// PropertyStoreExtension is a 0xBEEF0027 extension block. It holds
+// serialized property storage that describes the item.
+type PropertyStoreExtension struct {
+	Version uint16
+
+	// Store holds the serialized property storage.
+	Store []byte
+}
+
+// Signature returns the signature of the block.
+func (ext PropertyStoreExtension) Signature() ExtensionSignature {
+	return PropertyStoreExtensionBlock
+}
+
+// UnmarshalBinary decodes the extension block from data.
+func (ext *PropertyStoreExtension) UnmarshalBinary(data []byte) error {
+	version, err := parseExtensionHeader(data, PropertyStoreExtensionBlock, extensionHeaderSize)
+	if err != nil {
+		return err
+	}
+	*ext = PropertyStoreExtension{
+		Version: version,
+		Store:   clone(data[extensionHeaderSize:]),
+	}
+	return nil
+}
+
+// MarshalBinary returns the binary representation of the block.
+func (ext PropertyStoreExtension) MarshalBinary() ([]byte, error) {
+	data := appendExtensionHeader(PropertyStoreExtensionBlock, ext.Version)
+	return finishExtension(append(data, ext.Store...))
+}
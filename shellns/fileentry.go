@@ -0,0 +1,183 @@
+package shellns
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/gentlemanautomaton/winshell/internal/utf16le"
+)
+
+// File entry flags within the class type indicator.
+const (
+	FileEntryDirectory = 0x01
+	FileEntryFile      = 0x02
+	FileEntryUnicode   = 0x04
+)
+
+// FileEntryItem is an item that identifies a file or directory within a
+// file system.
+type FileEntryItem struct {
+	// Type is the class type indicator of the item, between 0x30 and
+	// 0x3F. The lower bits hold the FileEntryDirectory, FileEntryFile
+	// and FileEntryUnicode flags.
+	Type byte
+
+	// FileSize is the size of the file in bytes. It is limited to 32 bits.
+	FileSize uint32
+
+	// Modified is the last modification time of the file. It has a
+	// resolution of two seconds and does not include a time zone.
+	Modified time.Time
+
+	// Attributes holds the FILE_ATTRIBUTE values of the file.
+	Attributes uint16
+
+	// ShortName is the primary name of the file, which is usually its 8.3
+	// name. It is stored as UTF-16 if Type includes FileEntryUnicode.
+	ShortName string
+
+	// Extensions holds the extension blocks that follow the short name.
+	// Blocks with unrecognized signatures are held as RawExtension values.
+	Extensions []ExtensionBlock
+
+	// Extra holds any bytes that follow the extension blocks.
+	Extra []byte
+
+	unknown byte
+}
+
+// ClassType returns the class type indicator of the item.
+func (f FileEntryItem) ClassType() byte {
+	return f.Type
+}
+
+// IsDir returns true if the item identifies a directory.
+func (f FileEntryItem) IsDir() bool {
+	return f.Type&FileEntryDirectory != 0
+}
+
+// Name returns the long name of the file if the item has a file entry
+// extension block. Otherwise it returns the short name.
+func (f FileEntryItem) Name() string {
+	if ext := f.FileEntryExtension(); ext != nil && ext.LongName != "" {
+		return ext.LongName
+	}
+	return f.ShortName
+}
+
+// FileEntryExtension returns the file entry extension block of the item,
+// or nil if the item does not have one.
+func (f FileEntryItem) FileEntryExtension() *FileEntryExtension {
+	for _, ext := range f.Extensions {
+		if ext, ok := ext.(*FileEntryExtension); ok {
+			return ext
+		}
+	}
+	return nil
+}
+
+// UnmarshalBinary decodes a file entry item from data.
+func (f *FileEntryItem) UnmarshalBinary(data []byte) error {
+	if err := checkItem("file entry", data, 12); err != nil {
+		return err
+	}
+
+	*f = FileEntryItem{
+		Type:       data[0],
+		FileSize:   binary.LittleEndian.Uint32(data[2:6]),
+		Modified:   dosToTime(binary.LittleEndian.Uint16(data[6:8]), binary.LittleEndian.Uint16(data[8:10])),
+		Attributes: binary.LittleEndian.Uint16(data[10:12]),
+		unknown:    data[1],
+	}
+
+	offset := 12
+	if f.Type&FileEntryUnicode != 0 {
+		name, n, ok := utf16le.DecodeZ(data[offset:])
+		if !ok {
+			return fmt.Errorf("the file entry item name is not null-terminated")
+		}
+		f.ShortName = name
+		offset += n
+	} else {
+		name, n, ok := readString(data[offset:])
+		if !ok {
+			return fmt.Errorf("the file entry item name is not null-terminated")
+		}
+		f.ShortName = name
+		offset += n
+
+		// Single-byte names are padded to a 16 bit boundary
+		if offset%2 != 0 && offset < len(data) {
+			offset++
+		}
+	}
+
+	f.Extensions, f.Extra = parseExtensions(data[offset:])
+	f.Extra = clone(f.Extra)
+
+	return nil
+}
+
+// MarshalBinary returns the binary representation of the item.
+func (f FileEntryItem) MarshalBinary() ([]byte, error) {
+	if f.Type&classGroupMask != fileEntryGroup {
+		return nil, fmt.Errorf("the file entry item has an invalid class type of %#x", f.Type)
+	}
+
+	data := make([]byte, 12)
+	data[0] = f.Type
+	data[1] = f.unknown
+	binary.LittleEndian.PutUint32(data[2:6], f.FileSize)
+	date, tod := timeToDOS(f.Modified)
+	binary.LittleEndian.PutUint16(data[6:8], date)
+	binary.LittleEndian.PutUint16(data[8:10], tod)
+	binary.LittleEndian.PutUint16(data[10:12], f.Attributes)
+
+	if f.Type&FileEntryUnicode != 0 {
+		data = append(data, utf16le.EncodeZ(f.ShortName)...)
+	} else {
+		data = append(data, f.ShortName...)
+		data = append(data, 0)
+		if len(data)%2 != 0 {
+			data = append(data, 0)
+		}
+	}
+
+	// The offset of the first extension block is relative to the start of
+	// the item, including its 16 bit size
+	offset := uint16(len(data) + 2)
+	for _, ext := range f.Extensions {
+		block, err := withExtensionOffset(ext, offset).MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, block...)
+	}
+
+	return append(data, f.Extra...), nil
+}
+
+// withExtensionOffset returns ext with the offset of the first extension
+// block updated, if ext is a file entry extension block. Blocks of
+// version 3 and later end with the offset, including those held as raw
+// extension blocks. Extension blocks are always held by pointer, since
+// their UnmarshalBinary methods have pointer receivers.
+func withExtensionOffset(ext ExtensionBlock, offset uint16) ExtensionBlock {
+	switch ext := ext.(type) {
+	case *FileEntryExtension:
+		updated := *ext
+		updated.offset = offset
+		return &updated
+	case *RawExtension:
+		if ext.BlockSignature != FileEntryExtensionBlock || ext.Version < 3 || len(ext.Data) < 2 {
+			return ext
+		}
+		updated := *ext
+		updated.Data = clone(ext.Data)
+		binary.LittleEndian.PutUint16(updated.Data[len(updated.Data)-2:], offset)
+		return &updated
+	default:
+		return ext
+	}
+}
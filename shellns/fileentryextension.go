@@ -0,0 +1,223 @@
+package shellns
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/gentlemanautomaton/winshell/internal/utf16le"
+)
+
+// FileEntryExtension is a 0xBEEF0004 extension block. It holds the long
+// name, timestamps and NTFS file reference of a file entry item.
+//
+// The layout of the block depends on its version. Version 3 was written
+// by Windows XP, 7 by Windows Vista, 8 by Windows 7 and 9 by Windows 8
+// and later.
+type FileEntryExtension struct {
+	Version uint16
+
+	// Created and Accessed are the creation and last access times of the
+	// file. They have a resolution of two seconds and do not include a
+	// time zone.
+	Created  time.Time
+	Accessed time.Time
+
+	// FileReference is the NTFS file reference of the file. It is present
+	// in version 7 and later.
+	FileReference FileReference
+
+	// LongName is the long name of the file.
+	LongName string
+
+	// LocalizedName is the localized name of the file, if any. It is
+	// present in version 3 and later.
+	LocalizedName string
+
+	// offset is the offset of the first extension block within the item
+	// that holds this block. It is maintained by FileEntryItem.
+	offset uint16
+
+	localizedSize uint16
+	localized     string
+	unknown1      uint16
+	unknown2      [8]byte
+	unknown3      uint32
+	unknown4      uint32
+}
+
+// FileReference is an NTFS file reference. It identifies a file by its
+// master file table entry and sequence number.
+type FileReference uint64
+
+// Entry returns the master file table entry number of the file.
+func (ref FileReference) Entry() uint64 {
+	return uint64(ref) & 0x0000FFFFFFFFFFFF
+}
+
+// Sequence returns the sequence number of the master file table entry.
+func (ref FileReference) Sequence() uint16 {
+	return uint16(ref >> 48)
+}
+
+// Signature returns the signature of the block.
+func (ext FileEntryExtension) Signature() ExtensionSignature {
+	return FileEntryExtensionBlock
+}
+
+// nameOffset returns the offset of the long name within a block of the
+// given version.
+func (ext FileEntryExtension) nameOffset() int {
+	offset := 18
+	if ext.Version >= 7 {
+		offset += 18
+	}
+	if ext.Version >= 3 {
+		offset += 2
+	}
+	if ext.Version >= 9 {
+		offset += 4
+	}
+	if ext.Version >= 8 {
+		offset += 4
+	}
+	return offset
+}
+
+// UnmarshalBinary decodes the extension block from data.
+func (ext *FileEntryExtension) UnmarshalBinary(data []byte) error {
+	version, err := parseExtensionHeader(data, FileEntryExtensionBlock, 18)
+	if err != nil {
+		return err
+	}
+
+	*ext = FileEntryExtension{
+		Version:  version,
+		Created:  dosToTime(binary.LittleEndian.Uint16(data[8:10]), binary.LittleEndian.Uint16(data[10:12])),
+		Accessed: dosToTime(binary.LittleEndian.Uint16(data[12:14]), binary.LittleEndian.Uint16(data[14:16])),
+	}
+
+	offset := ext.nameOffset()
+	if len(data) < offset+2 {
+		return fmt.Errorf("the version %d file entry extension block requires at least %d bytes, but only %d bytes are present", version, offset+2, len(data))
+	}
+
+	if version >= 7 {
+		ext.unknown1 = binary.LittleEndian.Uint16(data[18:20])
+		ext.FileReference = FileReference(binary.LittleEndian.Uint64(data[20:28]))
+		copy(ext.unknown2[:], data[28:36])
+	}
+	pos := 36
+	if version < 7 {
+		pos = 18
+	}
+	if version >= 3 {
+		ext.localizedSize = binary.LittleEndian.Uint16(data[pos : pos+2])
+		pos += 2
+	}
+	if version >= 9 {
+		ext.unknown3 = binary.LittleEndian.Uint32(data[pos : pos+4])
+		pos += 4
+	}
+	if version >= 8 {
+		ext.unknown4 = binary.LittleEndian.Uint32(data[pos : pos+4])
+	}
+
+	// The final 16 bits of the block hold the offset of the first
+	// extension block
+	end := len(data)
+	if version >= 3 {
+		end -= 2
+		ext.offset = binary.LittleEndian.Uint16(data[end:])
+	}
+
+	name, n, ok := utf16le.DecodeZ(data[offset:end])
+	if !ok {
+		return fmt.Errorf("the file entry extension block long name is not null-terminated")
+	}
+	ext.LongName = name
+	offset += n
+
+	if version >= 3 && ext.localizedSize > 0 {
+		if version >= 7 {
+			name, n, ok = utf16le.DecodeZ(data[offset:end])
+		} else {
+			name, n, ok = readString(data[offset:end])
+		}
+		if !ok {
+			return fmt.Errorf("the file entry extension block localized name is not null-terminated")
+		}
+		ext.LocalizedName = name
+		ext.localized = name
+		offset += n
+	}
+
+	if offset != end {
+		return fmt.Errorf("the file entry extension block holds %d unexpected bytes", end-offset)
+	}
+
+	return nil
+}
+
+// MarshalBinary returns the binary representation of the block.
+func (ext FileEntryExtension) MarshalBinary() ([]byte, error) {
+	data := appendExtensionHeader(FileEntryExtensionBlock, ext.Version)
+	data = append(data, make([]byte, ext.nameOffset()-extensionHeaderSize)...)
+
+	date, tod := timeToDOS(ext.Created)
+	binary.LittleEndian.PutUint16(data[8:10], date)
+	binary.LittleEndian.PutUint16(data[10:12], tod)
+	date, tod = timeToDOS(ext.Accessed)
+	binary.LittleEndian.PutUint16(data[12:14], date)
+	binary.LittleEndian.PutUint16(data[14:16], tod)
+	binary.LittleEndian.PutUint16(data[16:18], uint16(ext.nameOffset()))
+
+	if ext.Version >= 7 {
+		binary.LittleEndian.PutUint16(data[18:20], ext.unknown1)
+		binary.LittleEndian.PutUint64(data[20:28], uint64(ext.FileReference))
+		copy(data[28:36], ext.unknown2[:])
+	}
+	pos := 36
+	if ext.Version < 7 {
+		pos = 18
+	}
+	sizePos := pos
+	if ext.Version >= 3 {
+		pos += 2
+	}
+	if ext.Version >= 9 {
+		binary.LittleEndian.PutUint32(data[pos:pos+4], ext.unknown3)
+		pos += 4
+	}
+	if ext.Version >= 8 {
+		binary.LittleEndian.PutUint32(data[pos:pos+4], ext.unknown4)
+	}
+
+	data = append(data, utf16le.EncodeZ(ext.LongName)...)
+
+	if ext.Version >= 3 {
+		// Keep the decoded size unless the localized name has changed
+		localizedSize := ext.localizedSize
+		if ext.LocalizedName != ext.localized {
+			localizedSize = 0
+		}
+		if ext.LocalizedName != "" {
+			var localized []byte
+			if ext.Version >= 7 {
+				localized = utf16le.EncodeZ(ext.LocalizedName)
+			} else {
+				localized = append([]byte(ext.LocalizedName), 0)
+			}
+			if localizedSize == 0 {
+				localizedSize = uint16(len(localized))
+			}
+			data = append(data, localized...)
+		} else {
+			localizedSize = 0
+		}
+		binary.LittleEndian.PutUint16(data[sizePos:sizePos+2], localizedSize)
+		data = binary.LittleEndian.AppendUint16(data, ext.offset)
+	}
+
+	return finishExtension(data)
+}
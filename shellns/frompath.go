@@ -0,0 +1,208 @@
+package shellns
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gentlemanautomaton/winshell/shellclass"
+	"github.com/gentlemanautomaton/winshell/winpath"
+)
+
+// File attributes assigned to file entry items by default.
+const (
+	fileAttributeDirectory = 0x0010
+	fileAttributeArchive   = 0x0020
+)
+
+// PathOptions control the items produced by FromPath.
+type PathOptions struct {
+	// Info returns information about a file or directory within the path.
+	// It is called once for each element of the path, with the path up to
+	// and including that element, such as C:\Users followed by
+	// C:\Users\alice.
+	//
+	// If Info is nil or returns false, default information is used. Each
+	// element is assumed to be a directory, except for the final element,
+	// which is assumed to be a file unless the path ends with a separator.
+	Info func(path string) (EntryInfo, bool)
+}
+
+// EntryInfo describes a file or directory within a path.
+type EntryInfo struct {
+	// Directory is true if the entry is a directory.
+	Directory bool
+
+	// Attributes holds the FILE_ATTRIBUTE values of the entry. If zero,
+	// FILE_ATTRIBUTE_DIRECTORY or FILE_ATTRIBUTE_ARCHIVE will be used.
+	Attributes uint16
+
+	// Size is the size of the file in bytes.
+	Size uint32
+
+	// Created, Modified and Accessed are the timestamps of the entry.
+	Created  time.Time
+	Modified time.Time
+	Accessed time.Time
+
+	// FileReference is the NTFS file reference of the entry.
+	FileReference FileReference
+
+	// ShortName is the 8.3 name of the entry. If empty, the long name will
+	// be used if it is a valid 8.3 name, otherwise a short name will be
+	// generated in the style of Windows.
+	ShortName string
+}
+
+// FromPath synthesizes an item ID list for a Windows file system path
+// that begins with a drive letter, such as C:\Users\alice\report.docx.
+// The list holds a My Computer root folder item, a volume item and a file
+// entry item for each element of the path.
+//
+// Forward slashes are accepted as separators. Paths with empty, "." or
+// ".." elements are rejected, since Windows never stores them in an ID
+// list. Such paths can be cleaned with winpath.Clean first.
+//
+// FromPath does not access the file system. Information about each
+// element of the path can be supplied through opts.
+func FromPath(path string, opts PathOptions) (List, error) {
+	path = winpath.FromSlash(path)
+	if winpath.Classify(path) != winpath.DriveAbsolute {
+		return nil, fmt.Errorf("the path \"%s\" does not begin with a drive letter", path)
+	}
+
+	root, err := RootFolderItem{
+		SortIndex: 0x50,
+		ClassID:   shellclass.MyComputer,
+	}.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	volume, err := VolumeItem{
+		Type:  0x2F,
+		Name:  strings.ToUpper(path[:2]) + `\`,
+		Extra: make([]byte, 18),
+	}.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	list := List{root, volume}
+
+	trimmed := strings.TrimRight(path[3:], `\`)
+	if trimmed == "" {
+		return list, nil
+	}
+
+	elements := strings.Split(trimmed, `\`)
+	current := path[:2]
+	for i, element := range elements {
+		switch element {
+		case "":
+			return nil, fmt.Errorf("the path \"%s\" contains an empty element", path)
+		case ".", "..":
+			return nil, fmt.Errorf("the path \"%s\" contains a relative \"%s\" element", path, element)
+		}
+		current += `\` + element
+
+		info, ok := EntryInfo{}, false
+		if opts.Info != nil {
+			info, ok = opts.Info(current)
+		}
+		if !ok {
+			info.Directory = i < len(elements)-1 || strings.HasSuffix(path, `\`)
+		}
+
+		item, err := fileEntry(element, info).MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, item)
+	}
+
+	return list, nil
+}
+
+// fileEntry returns a file entry item for the named file or directory,
+// with a file entry extension block in the format written by Windows 8
+// and later.
+func fileEntry(name string, info EntryInfo) FileEntryItem {
+	entry := FileEntryItem{
+		Modified:   info.Modified,
+		Attributes: info.Attributes,
+		ShortName:  info.ShortName,
+		Extensions: []ExtensionBlock{&FileEntryExtension{
+			Version:       9,
+			Created:       info.Created,
+			Accessed:      info.Accessed,
+			FileReference: info.FileReference,
+			LongName:      name,
+		}},
+	}
+
+	if info.Directory {
+		entry.Type = fileEntryGroup | FileEntryDirectory
+		if entry.Attributes == 0 {
+			entry.Attributes = fileAttributeDirectory
+		}
+	} else {
+		entry.Type = fileEntryGroup | FileEntryFile
+		entry.FileSize = info.Size
+		if entry.Attributes == 0 {
+			entry.Attributes = fileAttributeArchive
+		}
+	}
+
+	if entry.ShortName == "" {
+		entry.ShortName = shortName(name)
+	}
+
+	return entry
+}
+
+// shortName returns an 8.3 name for name. Names that already conform are
+// returned as-is, otherwise an approximation of the name generated by
+// Windows is returned.
+func shortName(name string) string {
+	base, ext := name, ""
+	if i := strings.LastIndexByte(name, '.'); i > 0 {
+		base, ext = name[:i], name[i+1:]
+	}
+	if len(base) <= 8 && len(ext) <= 3 && isShortNameText(base) && isShortNameText(ext) {
+		return name
+	}
+
+	clean := func(s string, max int) string {
+		var b strings.Builder
+		for _, r := range strings.ToUpper(s) {
+			if b.Len() >= max {
+				break
+			}
+			if r < 0x80 && isShortNameText(string(r)) {
+				b.WriteRune(r)
+			}
+		}
+		return b.String()
+	}
+
+	short := clean(base, 6) + "~1"
+	if ext = clean(ext, 3); ext != "" {
+		short += "." + ext
+	}
+	return short
+}
+
+// isShortNameText returns true if s contains only characters that are
+// valid within an 8.3 name.
+func isShortNameText(s string) bool {
+	for _, r := range s {
+		switch {
+		case r >= 'A' && r <= 'Z', r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+		case strings.ContainsRune("!#$%&'()-@^_`{}~", r):
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,73 @@
+package shellns_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gentlemanautomaton/winshell/shellns"
+)
+
+func ExampleFromPath() {
+	list, err := shellns.FromPath(`C:\Users\alice\Desktop\report.docx`, shellns.PathOptions{
+		Info: func(path string) (shellns.EntryInfo, bool) {
+			if path != `C:\Users\alice\Desktop\report.docx` {
+				return shellns.EntryInfo{}, false
+			}
+			return shellns.EntryInfo{
+				Size:     24576,
+				Modified: time.Date(2026, 3, 14, 9, 26, 52, 0, time.UTC),
+			}, true
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	for _, item := range list {
+		typed, err := item.Decode()
+		if err != nil {
+			panic(err)
+		}
+		switch typed := typed.(type) {
+		case *shellns.RootFolderItem:
+			fmt.Printf("root: {%s}\n", typed.ClassID)
+		case *shellns.VolumeItem:
+			fmt.Printf("volume: %s\n", typed.Name)
+		case *shellns.FileEntryItem:
+			fmt.Printf("entry: %s (%s, %d bytes, dir: %t)\n", typed.Name(), typed.ShortName, typed.FileSize, typed.IsDir())
+		}
+	}
+
+	// Output:
+	// root: {20d04fe0-3aea-1069-a2d8-08002b30309d}
+	// volume: C:\
+	// entry: Users (Users, 0 bytes, dir: true)
+	// entry: alice (alice, 0 bytes, dir: true)
+	// entry: Desktop (Desktop, 0 bytes, dir: true)
+	// entry: report.docx (REPORT~1.DOC, 24576 bytes, dir: false)
+}
+
+func TestFromPathErrors(t *testing.T) {
+	for _, path := range []string{`Users\alice`, `\\server\share\file`, `C:\Users\\alice`, `C:\a\..`, `C:\a\..\b`, `C:\.\a`, `C:\a\.\`} {
+		if _, err := shellns.FromPath(path, shellns.PathOptions{}); err == nil {
+			t.Errorf("%s: expected an error", path)
+		}
+	}
+}
+
+func TestFromPathVolumeItem(t *testing.T) {
+	list, err := shellns.FromPath(`c:\Users`, shellns.PathOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Windows writes the class type, the drive path, a terminal and 18
+	// bytes of padding
+	want, _ := hex.DecodeString("2f433a5c00" + "000000000000000000000000000000000000")
+	if !bytes.Equal(list[1], want) {
+		t.Errorf("volume item:\n got %x\nwant %x", []byte(list[1]), want)
+	}
+}
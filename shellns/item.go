@@ -1,4 +1,171 @@
 package shellns
 
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+)
+
 // Item is an Item ID within a shell namespace item ID list.
+//
+// The item's bytes exclude the 16 bit size that precedes it within a list.
+// The first byte of an item usually holds a class type indicator that
+// identifies the structure of the remaining bytes.
 type Item []byte
+
+// TypedItem is a decoded representation of an item. It is implemented by
+// RootFolderItem, VolumeItem, FileEntryItem, NetworkLocationItem,
+// URIItem, ControlPanelItem and UnknownItem.
+//
+// The binary representation of a typed item is suitable for use as an
+// Item.
+type TypedItem interface {
+	ClassType() byte
+	MarshalBinary() ([]byte, error)
+}
+
+// ClassType returns the class type indicator of the item. It returns zero
+// if the item is empty.
+func (item Item) ClassType() byte {
+	if len(item) == 0 {
+		return 0
+	}
+	return item[0]
+}
+
+// Decode returns a typed representation of the item. Items with an
+// unrecognized class type are returned as an UnknownItem.
+func (item Item) Decode() (TypedItem, error) {
+	if len(item) == 0 {
+		return nil, errors.New("the shell namespace item is empty")
+	}
+
+	var typed interface {
+		TypedItem
+		UnmarshalBinary([]byte) error
+	}
+
+	switch t := item[0]; {
+	case t == rootFolderType:
+		typed = new(RootFolderItem)
+	case t&classGroupMask == volumeGroup:
+		typed = new(VolumeItem)
+	case t&classGroupMask == fileEntryGroup:
+		typed = new(FileEntryItem)
+	case t&classGroupMask == networkLocationGroup:
+		typed = new(NetworkLocationItem)
+	case t == uriType:
+		typed = new(URIItem)
+	case t == controlPanelType:
+		typed = new(ControlPanelItem)
+	default:
+		typed = new(UnknownItem)
+	}
+
+	if err := typed.UnmarshalBinary(item); err != nil {
+		return nil, err
+	}
+
+	return typed, nil
+}
+
+// Class type indicators and groups.
+const (
+	classGroupMask       = 0x70
+	volumeGroup          = 0x20
+	fileEntryGroup       = 0x30
+	networkLocationGroup = 0x40
+
+	rootFolderType   = 0x1F
+	uriType          = 0x61
+	controlPanelType = 0x71
+)
+
+// checkItem returns an error if data is shorter than min bytes.
+func checkItem(kind string, data []byte, min int) error {
+	if len(data) < min {
+		return fmt.Errorf("the %s item requires at least %d bytes, but only %d bytes are present", kind, min, len(data))
+	}
+	return nil
+}
+
+// readString reads a null-terminated string of single-byte characters
+// from the start of b. It returns the string and the number of bytes
+// consumed, including the terminator.
+//
+// The bytes are preserved as-is, so that strings in an ANSI code page
+// other than ASCII can be marshaled again without loss.
+func readString(b []byte) (s string, n int, ok bool) {
+	end := bytes.IndexByte(b, 0)
+	if end < 0 {
+		return "", 0, false
+	}
+	return string(b[:end]), end + 1, true
+}
+
+// clone returns a copy of b, or nil if b is empty.
+func clone(b []byte) []byte {
+	if len(b) == 0 {
+		return nil
+	}
+	return append([]byte(nil), b...)
+}
+
+// Clone returns a copy of the item.
+func (item Item) Clone() Item {
+	if item == nil {
+		return nil
+	}
+	return append(Item{}, item...)
+}
+
+// Equal returns true if item and other identify the same shell namespace
+// object.
+//
+// Items of recognized types are compared by their identifying fields,
+// in the manner of the shell. File entries are compared by their long
+// names without regard to case, root folders and control panel items are
+// compared by class identifier, and volumes and network locations are
+// compared by name without regard to case. Other items are equal only if
+// their bytes are identical.
+func (item Item) Equal(other Item) bool {
+	if bytes.Equal(item, other) {
+		return true
+	}
+
+	a, err := item.Decode()
+	if err != nil {
+		return false
+	}
+	b, err := other.Decode()
+	if err != nil {
+		return false
+	}
+
+	switch a := a.(type) {
+	case *RootFolderItem:
+		b, ok := b.(*RootFolderItem)
+		return ok && a.ClassID == b.ClassID
+	case *VolumeItem:
+		b, ok := b.(*VolumeItem)
+		if !ok || (a.Type == volumeShellFolder) != (b.Type == volumeShellFolder) {
+			return false
+		}
+		if a.Type == volumeShellFolder {
+			return a.ClassID == b.ClassID
+		}
+		return strings.EqualFold(a.Name, b.Name)
+	case *FileEntryItem:
+		b, ok := b.(*FileEntryItem)
+		return ok && a.IsDir() == b.IsDir() && strings.EqualFold(a.Name(), b.Name())
+	case *NetworkLocationItem:
+		b, ok := b.(*NetworkLocationItem)
+		return ok && strings.EqualFold(a.Location, b.Location)
+	case *ControlPanelItem:
+		b, ok := b.(*ControlPanelItem)
+		return ok && a.ClassID == b.ClassID
+	default:
+		return false
+	}
+}
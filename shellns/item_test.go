@@ -0,0 +1,99 @@
+package shellns_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/gentlemanautomaton/winshell/shellclass"
+	"github.com/gentlemanautomaton/winshell/shellns"
+)
+
+var (
+	myComputerItem = shellns.Item{0x1F, 0x50, 0xE0, 0x4F, 0xD0, 0x20, 0xEA, 0x3A, 0x69, 0x10, 0xA2, 0xD8, 0x08, 0x00, 0x2B, 0x30, 0x30, 0x9D}
+	driveItem      = shellns.Item{0x2F, 'C', ':', '\\', 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+	usersItem      = shellns.Item{
+		0x31, 0x00, 0x00, 0x00, 0x00, 0x00, 0x38, 0x4F, 0xC5, 0x9A, 0x11, 0x00,
+		'U', 's', 'e', 'r', 's', 0x00,
+		0x22, 0x00, 0x03, 0x00, 0x04, 0x00, 0xEF, 0xBE, 0x38, 0x4F, 0xC5, 0x9A, 0x38, 0x4F, 0xC5, 0x9A, 0x14, 0x00, 0x00, 0x00,
+		'U', 0, 's', 0, 'e', 0, 'r', 0, 's', 0, 0, 0, 0x14, 0x00,
+	}
+	serverItem = shellns.Item{0x42, 0x01, 0x80, '\\', '\\', 'S', 'R', 'V', 0x00, 'M', 'S', ' ', 'N', 'e', 't', 0x00, 0x00, 0x00}
+	uriItem    = shellns.Item{0x61, 0x80, 0x00, 0x00, 'f', 0, 't', 0, 'p', 0, ':', 0, '/', 0, '/', 0, 'x', 0, 0, 0}
+	cplItem    = shellns.Item{
+		0x71, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0xE0, 0x4F, 0xD0, 0x20, 0xEA, 0x3A, 0x69, 0x10, 0xA2, 0xD8, 0x08, 0x00, 0x2B, 0x30, 0x30, 0x9D,
+	}
+	unknownItem = shellns.Item{0x74, 0x1A, 0x00, 0x43, 0x46, 0x53, 0x46}
+
+	// Items with a nonzero second byte, which has no documented meaning
+	shellFolderVolumeItem = shellns.Item{
+		0x2E, 0x80,
+		0xE0, 0x4F, 0xD0, 0x20, 0xEA, 0x3A, 0x69, 0x10, 0xA2, 0xD8, 0x08, 0x00, 0x2B, 0x30, 0x30, 0x9D,
+	}
+	oddFileItem = shellns.Item{0x32, 0x5A, 0x00, 0x04, 0x00, 0x00, 0x38, 0x4F, 0xC5, 0x9A, 0x20, 0x00, 'A', '.', 'T', 'X', 'T', 0x00}
+)
+
+func TestItemDecode(t *testing.T) {
+	for _, item := range []shellns.Item{myComputerItem, driveItem, usersItem, serverItem, uriItem, cplItem, unknownItem, shellFolderVolumeItem, oddFileItem} {
+		typed, err := item.Decode()
+		if err != nil {
+			t.Fatalf("%x: %v", item, err)
+		}
+		if got, want := typed.ClassType(), item.ClassType(); got != want {
+			t.Errorf("%x: class type: got %#x, want %#x", item, got, want)
+		}
+		data, err := typed.MarshalBinary()
+		if err != nil {
+			t.Fatalf("%x: %v", item, err)
+		}
+		if !bytes.Equal(data, item) {
+			t.Errorf("%T: marshaled item differs from the original:\n got %x\nwant %x", typed, data, item)
+		}
+	}
+}
+
+func TestItemDecodeFields(t *testing.T) {
+	decode := func(item shellns.Item) shellns.TypedItem {
+		typed, err := item.Decode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return typed
+	}
+
+	if root, ok := decode(myComputerItem).(*shellns.RootFolderItem); !ok || root.ClassID != shellclass.MyComputer {
+		t.Errorf("root folder: got %#v", root)
+	}
+
+	if volume, ok := decode(driveItem).(*shellns.VolumeItem); !ok || volume.Name != `C:\` {
+		t.Errorf("volume: got %#v", volume)
+	}
+
+	entry, ok := decode(usersItem).(*shellns.FileEntryItem)
+	if !ok {
+		t.Fatalf("file entry: got %T", entry)
+	}
+	if entry.ShortName != "Users" || !entry.IsDir() || entry.Attributes != 0x11 {
+		t.Errorf("file entry: got %#v", entry)
+	}
+	if want := time.Date(2019, 9, 24, 19, 22, 10, 0, time.UTC); !entry.Modified.Equal(want) {
+		t.Errorf("file entry modified: got %s, want %s", entry.Modified, want)
+	}
+
+	if network, ok := decode(serverItem).(*shellns.NetworkLocationItem); !ok || network.Location != `\\SRV` || network.Description != "MS Net" {
+		t.Errorf("network location: got %#v", network)
+	}
+
+	if uri, ok := decode(uriItem).(*shellns.URIItem); !ok || uri.URI != "ftp://x" {
+		t.Errorf("uri: got %#v", uri)
+	}
+
+	if cpl, ok := decode(cplItem).(*shellns.ControlPanelItem); !ok || cpl.ClassID != shellclass.MyComputer {
+		t.Errorf("control panel: got %#v", cpl)
+	}
+
+	if unknown, ok := decode(unknownItem).(*shellns.UnknownItem); !ok || unknown.Type != 0x74 {
+		t.Errorf("unknown: got %#v", unknown)
+	}
+}
@@ -3,6 +3,7 @@ package shellns
 import (
 	"encoding/binary"
 	"fmt"
+	"io"
 )
 
 // List is a list of shell namespace item IDs.
@@ -48,13 +49,15 @@ func (list List) MarshalBinaryTo(data []byte) (err error) {
 		return fmt.Errorf("the shell namespace item ID list requires %d bytes, but the buffer provided holds %d bytes", size, len(data))
 	}
 
-	// Write a 16 bit list header with the size
-	binary.LittleEndian.PutUint16(data[0:2], uint16(len(list)))
+	// Write a 16 bit list header with the size of the items and terminal,
+	// which excludes the header itself
+	binary.LittleEndian.PutUint16(data[0:2], uint16(size-2))
 	offset := 2
 
 	for _, item := range list {
-		// Write a 16 bit item header with the size
-		binary.LittleEndian.PutUint16(data[offset:offset+2], uint16(len(item)))
+		// Write a 16 bit item header with the size, which includes the
+		// header itself
+		binary.LittleEndian.PutUint16(data[offset:offset+2], uint16(len(item)+2))
 		offset += 2
 
 		// Write the item bytes
@@ -78,3 +81,193 @@ func (list List) MarshalBinary() (data []byte, err error) {
 	return data, list.MarshalBinaryTo(data)
 
 }
+
+// UnmarshalBinary parses a binary representation of a shell link item ID
+// list from data.
+//
+// The data must hold a LinkTargetIDList, which begins with a 16 bit size
+// and ends with a terminal. The size must match the length of data:
+// https://docs.microsoft.com/en-us/openspecs/windows_protocols/ms-shllink/881d7a83-07a5-4702-93e3-f9fc34c3e1e4
+func (list *List) UnmarshalBinary(data []byte) error {
+	if len(data) < 2 {
+		return fmt.Errorf("%w: the list header requires 2 bytes, but only %d bytes are present", ErrTruncated, len(data))
+	}
+
+	size := int(binary.LittleEndian.Uint16(data[0:2]))
+	switch remaining := len(data) - 2; {
+	case remaining < size:
+		return fmt.Errorf("%w: the list declares a size of %d bytes, but only %d bytes are present", ErrTruncated, size, remaining)
+	case remaining > size:
+		return fmt.Errorf("%w: the list declares a size of %d bytes, but %d bytes are present", ErrTrailingData, size, remaining)
+	}
+
+	return list.unmarshalItems(data[2:])
+}
+
+// ReadFrom reads a binary representation of a shell link item ID list
+// from r. It reads the 16 bit list size and then exactly that many bytes.
+// It returns the number of bytes read.
+//
+// ReadFrom implements the io.ReaderFrom interface.
+func (list *List) ReadFrom(r io.Reader) (n int64, err error) {
+	var header [2]byte
+	hn, err := io.ReadFull(r, header[:])
+	n += int64(hn)
+	if err != nil {
+		return n, fmt.Errorf("%w: failed to read the list header: %v", ErrTruncated, err)
+	}
+
+	data := make([]byte, binary.LittleEndian.Uint16(header[:]))
+	dn, err := io.ReadFull(r, data)
+	n += int64(dn)
+	if err != nil {
+		return n, fmt.Errorf("%w: the list declares a size of %d bytes, but only %d bytes could be read: %v", ErrTruncated, len(data), dn, err)
+	}
+
+	return n, list.unmarshalItems(data)
+}
+
+// unmarshalItems parses a sequence of items followed by a terminal from
+// data. The terminal must be the last thing in data.
+func (list *List) unmarshalItems(data []byte) error {
+	items := List{}
+	offset := 0
+	for {
+		remaining := len(data) - offset
+		if remaining == 0 {
+			return ErrMissingTerminal
+		}
+		if remaining < 2 {
+			return fmt.Errorf("%w: %d byte remains at offset %d where an item size or terminal was expected", ErrTruncated, remaining, offset)
+		}
+
+		size := int(binary.LittleEndian.Uint16(data[offset : offset+2]))
+		if size == 0 {
+			if remaining > 2 {
+				return fmt.Errorf("%w: %d bytes follow the terminal at offset %d", ErrTrailingData, remaining-2, offset)
+			}
+			*list = items
+			return nil
+		}
+		if size < 2 || size > remaining {
+			return ItemSizeError{Index: len(items), Offset: offset, Size: size, Remaining: remaining}
+		}
+
+		item := make(Item, size-2)
+		copy(item, data[offset+2:offset+size])
+		items = append(items, item)
+		offset += size
+	}
+}
+
+// Parent returns the list without its last item. It returns an empty list
+// if the list is empty. It is analogous to ILRemoveLastID.
+//
+// The returned list shares its items with the original.
+func (list List) Parent() List {
+	if len(list) == 0 {
+		return List{}
+	}
+	n := len(list) - 1
+	return list[:n:n]
+}
+
+// Last returns the last item in the list, or nil if the list is empty. It
+// is analogous to ILFindLastID.
+func (list List) Last() Item {
+	if len(list) == 0 {
+		return nil
+	}
+	return list[len(list)-1]
+}
+
+// Append returns a new list with a copy of item appended to it. The
+// original list is not modified. It is analogous to ILAppendID.
+func (list List) Append(item Item) List {
+	return list.Concat(List{item})
+}
+
+// Concat returns a new list that holds copies of the items in list
+// followed by copies of the items in other. It is analogous to ILCombine.
+func (list List) Concat(other List) List {
+	combined := make(List, 0, len(list)+len(other))
+	for _, item := range list {
+		combined = append(combined, item.Clone())
+	}
+	for _, item := range other {
+		combined = append(combined, item.Clone())
+	}
+	return combined
+}
+
+// Clone returns a deep copy of the list. It is analogous to ILClone.
+func (list List) Clone() List {
+	if list == nil {
+		return nil
+	}
+	return list.Concat(nil)
+}
+
+// Equal returns true if list and other hold the same number of items and
+// each pair of items is equal, as determined by Item.Equal. It is
+// analogous to ILIsEqual.
+func (list List) Equal(other List) bool {
+	if len(list) != len(other) {
+		return false
+	}
+	for i := range list {
+		if !list[i].Equal(other[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsParentOf returns true if list is an ancestor of child. If immediate
+// is true, list must be the immediate parent of child. A list is not a
+// parent of itself. It is analogous to ILIsParent.
+func (list List) IsParentOf(child List, immediate bool) bool {
+	if len(child) <= len(list) {
+		return false
+	}
+	if immediate && len(child) != len(list)+1 {
+		return false
+	}
+	return list.Equal(child[:len(list)])
+}
+
+// Offset returns the byte offset of the item at index i within the
+// binary representation of the list, measured from the start of the
+// first item. The list's own size header is not included. An index equal
+// to the length of the list returns the offset of the terminal.
+//
+// Offsets of this form are used by shell link data blocks to refer to an
+// item within a link's target ID list.
+func (list List) Offset(i int) int {
+	offset := 0
+	for _, item := range list[:i] {
+		offset += 2 + len(item)
+	}
+	return offset
+}
+
+// IndexAt returns the index of the item that begins at the given byte
+// offset, as returned by Offset. If the offset refers to the terminal,
+// it returns the length of the list. It returns false if no item begins
+// at the offset.
+func (list List) IndexAt(offset int) (index int, ok bool) {
+	position := 0
+	for i, item := range list {
+		if position == offset {
+			return i, true
+		}
+		if position > offset {
+			return 0, false
+		}
+		position += 2 + len(item)
+	}
+	if position == offset {
+		return len(list), true
+	}
+	return 0, false
+}
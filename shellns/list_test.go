@@ -0,0 +1,99 @@
+package shellns_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/gentlemanautomaton/winshell/shellns"
+)
+
+func TestListRoundTrip(t *testing.T) {
+	list := shellns.List{
+		shellns.Item{0x1F, 0x50, 0xE0, 0x4F, 0xD0, 0x20, 0xEA, 0x3A, 0x69, 0x10, 0xA2, 0xD8, 0x08, 0x00, 0x2B, 0x30, 0x30, 0x9D},
+		shellns.Item{0x2F, 'C', ':', '\\', 0x00},
+	}
+
+	data, err := list.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded shellns.List
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded) != len(list) {
+		t.Fatalf("got %d items, want %d", len(decoded), len(list))
+	}
+	for i := range list {
+		if !bytes.Equal(decoded[i], list[i]) {
+			t.Errorf("item %d: got %x, want %x", i, decoded[i], list[i])
+		}
+	}
+
+	var streamed shellns.List
+	n, err := streamed.ReadFrom(bytes.NewReader(append(data, 0xFF, 0xFF)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(len(data)) {
+		t.Errorf("read %d bytes, want %d", n, len(data))
+	}
+	if len(streamed) != len(list) {
+		t.Errorf("streamed: got %d items, want %d", len(streamed), len(list))
+	}
+}
+
+func TestListUnmarshalBinaryEmpty(t *testing.T) {
+	var list shellns.List
+	if err := list.UnmarshalBinary([]byte{0x02, 0x00, 0x00, 0x00}); err != nil {
+		t.Fatal(err)
+	}
+	if list == nil || len(list) != 0 {
+		t.Errorf("got %#v, want an empty non-nil list", list)
+	}
+}
+
+func TestListUnmarshalBinaryErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"NoHeader", []byte{0x04}, shellns.ErrTruncated},
+		{"ShortList", []byte{0x08, 0x00, 0x04, 0x00, 0xAA, 0xBB}, shellns.ErrTruncated},
+		{"LongList", []byte{0x02, 0x00, 0x00, 0x00, 0x00}, shellns.ErrTrailingData},
+		{"NoTerminal", []byte{0x04, 0x00, 0x04, 0x00, 0xAA, 0xBB}, shellns.ErrMissingTerminal},
+		{"SplitTerminal", []byte{0x05, 0x00, 0x04, 0x00, 0xAA, 0xBB, 0x00}, shellns.ErrTruncated},
+		{"DataAfterTerminal", []byte{0x04, 0x00, 0x00, 0x00, 0xAA, 0xBB}, shellns.ErrTrailingData},
+		{"OverlongItem", []byte{0x06, 0x00, 0x09, 0x00, 0xAA, 0xBB, 0x00, 0x00}, shellns.ErrInvalidItemSize},
+		{"UndersizedItem", []byte{0x04, 0x00, 0x01, 0x00, 0x00, 0x00}, shellns.ErrInvalidItemSize},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var list shellns.List
+			err := list.UnmarshalBinary(test.data)
+			if !errors.Is(err, test.want) {
+				t.Errorf("got %v, want %v", err, test.want)
+			}
+		})
+	}
+}
+
+func TestListItemSizeError(t *testing.T) {
+	data := []byte{0x0A, 0x00, 0x04, 0x00, 0xAA, 0xBB, 0x09, 0x00, 0xCC, 0xDD, 0x00, 0x00}
+
+	var list shellns.List
+	err := list.UnmarshalBinary(data)
+
+	var sizeErr shellns.ItemSizeError
+	if !errors.As(err, &sizeErr) {
+		t.Fatalf("got %v, want an ItemSizeError", err)
+	}
+	want := shellns.ItemSizeError{Index: 1, Offset: 4, Size: 9, Remaining: 6}
+	if sizeErr != want {
+		t.Errorf("got %+v, want %+v", sizeErr, want)
+	}
+}
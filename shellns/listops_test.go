@@ -0,0 +1,93 @@
+package shellns_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gentlemanautomaton/winshell/shellns"
+)
+
+func TestListOperations(t *testing.T) {
+	report, err := shellns.FromPath(`C:\Users\alice\report.docx`, shellns.PathOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A list for the same path with different case and metadata
+	other, err := shellns.FromPath(`c:\USERS\Alice\REPORT.DOCX`, shellns.PathOptions{
+		Info: func(path string) (shellns.EntryInfo, bool) {
+			return shellns.EntryInfo{
+				Directory: len(path) < len(`c:\USERS\Alice\REPORT.DOCX`),
+				Modified:  time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			}, true
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !report.Equal(other) {
+		t.Error("lists that differ only by case and metadata are not equal")
+	}
+
+	parent := report.Parent()
+	if got, want := parent.ParsingName(), `C:\Users\alice`; got != want {
+		t.Errorf("parent: got %q, want %q", got, want)
+	}
+	if !parent.IsParentOf(report, true) {
+		t.Error("the parent is not the immediate parent of the list")
+	}
+	if !parent.Parent().IsParentOf(report, false) {
+		t.Error("the grandparent is not a parent of the list")
+	}
+	if parent.Parent().IsParentOf(report, true) {
+		t.Error("the grandparent is reported as the immediate parent of the list")
+	}
+	if report.IsParentOf(report, false) {
+		t.Error("the list is reported as a parent of itself")
+	}
+
+	rebuilt := parent.Append(report.Last())
+	if !rebuilt.Equal(report) {
+		t.Error("appending the last item to the parent did not reproduce the list")
+	}
+
+	combined := parent.Parent().Concat(report[len(report)-2:])
+	if got, want := combined.ParsingName(), `C:\Users\alice\report.docx`; got != want {
+		t.Errorf("concat: got %q, want %q", got, want)
+	}
+
+	clone := report.Clone()
+	clone[0][1] = 0xFF
+	if report[0][1] == 0xFF {
+		t.Error("modifying the clone modified the original")
+	}
+
+	// Appending to a parent must not overwrite the original list
+	parent.Append(shellns.Item{0x74})
+	if got, want := report.ParsingName(), `C:\Users\alice\report.docx`; got != want {
+		t.Errorf("after append: got %q, want %q", got, want)
+	}
+}
+
+func TestListOffset(t *testing.T) {
+	list, err := shellns.FromPath(`C:\Users\alice\report.docx`, shellns.PathOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i <= len(list); i++ {
+		offset := list.Offset(i)
+		index, ok := list.IndexAt(offset)
+		if !ok || index != i {
+			t.Errorf("item %d: offset %d maps to index %d (%t)", i, offset, index, ok)
+		}
+	}
+
+	if got, want := list.Offset(len(list)), list.Size()-4; got != want {
+		t.Errorf("terminal offset: got %d, want %d", got, want)
+	}
+	if _, ok := list.IndexAt(1); ok {
+		t.Error("an offset within the first item was accepted")
+	}
+}
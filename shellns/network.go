@@ -0,0 +1,103 @@
+package shellns
+
+import "fmt"
+
+// Network location flags.
+const (
+	networkHasComments    = 0x40
+	networkHasDescription = 0x80
+)
+
+// NetworkLocationItem is an item that identifies a location on a network,
+// such as a domain, server or share.
+type NetworkLocationItem struct {
+	// Type is the class type indicator of the item, between 0x40 and
+	// 0x4F. Common values include 0x41 for a domain, 0x42 for a server
+	// and 0x43 for a share.
+	Type byte
+
+	// Location is the network location, such as \\server\share.
+	Location string
+
+	// Description describes the location, such as the name of the
+	// network provider.
+	Description string
+
+	// Comments holds additional comments about the location.
+	Comments string
+
+	// Extra holds any bytes that follow the strings.
+	Extra []byte
+
+	unknown byte
+}
+
+// ClassType returns the class type indicator of the item.
+func (n NetworkLocationItem) ClassType() byte {
+	return n.Type
+}
+
+// UnmarshalBinary decodes a network location item from data.
+func (n *NetworkLocationItem) UnmarshalBinary(data []byte) error {
+	if err := checkItem("network location", data, 4); err != nil {
+		return err
+	}
+
+	*n = NetworkLocationItem{Type: data[0], unknown: data[1]}
+	flags := data[2]
+	offset := 3
+
+	fields := []struct {
+		present bool
+		name    string
+		value   *string
+	}{
+		{true, "location", &n.Location},
+		{flags&networkHasDescription != 0, "description", &n.Description},
+		{flags&networkHasComments != 0, "comments", &n.Comments},
+	}
+	for _, field := range fields {
+		if !field.present {
+			continue
+		}
+		s, size, ok := readString(data[offset:])
+		if !ok {
+			return fmt.Errorf("the network location item %s is not null-terminated", field.name)
+		}
+		*field.value = s
+		offset += size
+	}
+
+	n.Extra = clone(data[offset:])
+
+	return nil
+}
+
+// MarshalBinary returns the binary representation of the item.
+func (n NetworkLocationItem) MarshalBinary() ([]byte, error) {
+	if n.Type&classGroupMask != networkLocationGroup {
+		return nil, fmt.Errorf("the network location item has an invalid class type of %#x", n.Type)
+	}
+
+	var flags byte
+	if n.Description != "" {
+		flags |= networkHasDescription
+	}
+	if n.Comments != "" {
+		flags |= networkHasComments
+	}
+
+	data := []byte{n.Type, n.unknown, flags}
+	data = append(data, n.Location...)
+	data = append(data, 0)
+	if n.Description != "" {
+		data = append(data, n.Description...)
+		data = append(data, 0)
+	}
+	if n.Comments != "" {
+		data = append(data, n.Comments...)
+		data = append(data, 0)
+	}
+
+	return append(data, n.Extra...), nil
+}
@@ -0,0 +1,49 @@
+package shellns
+
+import (
+	"github.com/gentlemanautomaton/winshell/internal/guid"
+	"github.com/google/uuid"
+)
+
+// RootFolderItem is an item that identifies a root shell folder, such as
+// My Computer, by its class identifier. It usually appears first in an
+// item ID list.
+type RootFolderItem struct {
+	// SortIndex determines the order of the folder among other root
+	// folders, such as 0x50 for My Computer.
+	SortIndex byte
+
+	// ClassID is the class identifier of the folder, such as
+	// shellclass.MyComputer.
+	ClassID uuid.UUID
+
+	// Extra holds any bytes that follow the class identifier.
+	Extra []byte
+}
+
+// ClassType returns the class type indicator of the item.
+func (r RootFolderItem) ClassType() byte {
+	return rootFolderType
+}
+
+// UnmarshalBinary decodes a root folder item from data.
+func (r *RootFolderItem) UnmarshalBinary(data []byte) error {
+	if err := checkItem("root folder", data, 18); err != nil {
+		return err
+	}
+	*r = RootFolderItem{
+		SortIndex: data[1],
+		ClassID:   guid.Decode(data[2:18]),
+		Extra:     clone(data[18:]),
+	}
+	return nil
+}
+
+// MarshalBinary returns the binary representation of the item.
+func (r RootFolderItem) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 18, 18+len(r.Extra))
+	data[0] = rootFolderType
+	data[1] = r.SortIndex
+	guid.Put(data[2:18], r.ClassID)
+	return append(data, r.Extra...), nil
+}
@@ -0,0 +1,30 @@
+package shellns
+
+// UnknownItem is an item with a class type that is not recognized. It
+// holds the item's bytes verbatim.
+type UnknownItem struct {
+	// Type is the class type indicator of the item.
+	Type byte
+
+	// Data holds the bytes of the item that follow the class type.
+	Data []byte
+}
+
+// ClassType returns the class type indicator of the item.
+func (u UnknownItem) ClassType() byte {
+	return u.Type
+}
+
+// UnmarshalBinary decodes an unknown item from data.
+func (u *UnknownItem) UnmarshalBinary(data []byte) error {
+	if err := checkItem("unknown", data, 1); err != nil {
+		return err
+	}
+	*u = UnknownItem{Type: data[0], Data: clone(data[1:])}
+	return nil
+}
+
+// MarshalBinary returns the binary representation of the item.
+func (u UnknownItem) MarshalBinary() ([]byte, error) {
+	return append([]byte{u.Type}, u.Data...), nil
+}
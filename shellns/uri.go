@@ -0,0 +1,97 @@
+package shellns
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/gentlemanautomaton/winshell/internal/utf16le"
+)
+
+// URI item flags.
+const (
+	uriUnicode = 0x80
+)
+
+// URIItem is an item that identifies a uniform resource identifier, such
+// as an FTP or HTTP location.
+type URIItem struct {
+	// Flags hold the flags of the item. If the 0x80 bit is set the URI is
+	// stored as UTF-16.
+	Flags byte
+
+	// Data holds the variable length data that precedes the URI, which
+	// may include timestamps and credentials.
+	Data []byte
+
+	// URI is the uniform resource identifier.
+	URI string
+
+	// Extra holds any bytes that follow the URI.
+	Extra []byte
+}
+
+// ClassType returns the class type indicator of the item.
+func (u URIItem) ClassType() byte {
+	return uriType
+}
+
+// UnmarshalBinary decodes a URI item from data.
+func (u *URIItem) UnmarshalBinary(data []byte) error {
+	if err := checkItem("URI", data, 4); err != nil {
+		return err
+	}
+
+	size := int(binary.LittleEndian.Uint16(data[2:4]))
+	if len(data)-4 < size {
+		return fmt.Errorf("the URI item declares %d bytes of data, but only %d bytes remain", size, len(data)-4)
+	}
+
+	*u = URIItem{
+		Flags: data[1],
+		Data:  clone(data[4 : 4+size]),
+	}
+
+	offset := 4 + size
+	if offset == len(data) {
+		return nil
+	}
+
+	var (
+		n  int
+		ok bool
+	)
+	if u.Flags&uriUnicode != 0 {
+		u.URI, n, ok = utf16le.DecodeZ(data[offset:])
+	} else {
+		u.URI, n, ok = readString(data[offset:])
+	}
+	if !ok {
+		return fmt.Errorf("the URI item's URI is not null-terminated")
+	}
+
+	u.Extra = clone(data[offset+n:])
+
+	return nil
+}
+
+// MarshalBinary returns the binary representation of the item.
+func (u URIItem) MarshalBinary() ([]byte, error) {
+	if len(u.Data) > 65535 {
+		return nil, fmt.Errorf("the URI item holds %d bytes of data, which exceeds the limit of 65535", len(u.Data))
+	}
+
+	data := []byte{uriType, u.Flags}
+	data = binary.LittleEndian.AppendUint16(data, uint16(len(u.Data)))
+	data = append(data, u.Data...)
+
+	if u.URI != "" || len(u.Extra) > 0 {
+		if u.Flags&uriUnicode != 0 {
+			data = append(data, utf16le.EncodeZ(u.URI)...)
+		} else {
+			data = append(data, u.URI...)
+			data = append(data, 0)
+		}
+	}
+
+	return append(data, u.Extra...), nil
+}
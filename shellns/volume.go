@@ -0,0 +1,85 @@
+package shellns
+
+import (
+	"fmt"
+
+	"github.com/gentlemanautomaton/winshell/internal/guid"
+	"github.com/google/uuid"
+)
+
+// Volume item flags within the class type indicator.
+const (
+	volumeHasName     = 0x01
+	volumeShellFolder = 0x2E
+)
+
+// VolumeItem is an item that identifies a volume, such as a drive letter.
+type VolumeItem struct {
+	// Type is the class type indicator of the item, between 0x20 and
+	// 0x2F. The common value of 0x2F indicates a volume with a drive
+	// letter name.
+	Type byte
+
+	// Name is the drive path of the volume, such as "C:\". It is present
+	// when Type has its lowest bit set.
+	Name string
+
+	// ClassID is the class identifier of a shell folder. It is present
+	// when Type is 0x2E.
+	ClassID uuid.UUID
+
+	// Extra holds any bytes that follow the name or class identifier,
+	// such as padding.
+	Extra []byte
+
+	unknown byte
+}
+
+// ClassType returns the class type indicator of the item.
+func (v VolumeItem) ClassType() byte {
+	return v.Type
+}
+
+// UnmarshalBinary decodes a volume item from data.
+func (v *VolumeItem) UnmarshalBinary(data []byte) error {
+	if err := checkItem("volume", data, 1); err != nil {
+		return err
+	}
+	*v = VolumeItem{Type: data[0]}
+	switch {
+	case v.Type == volumeShellFolder:
+		if err := checkItem("volume", data, 18); err != nil {
+			return err
+		}
+		v.unknown = data[1]
+		v.ClassID = guid.Decode(data[2:18])
+		v.Extra = clone(data[18:])
+	case v.Type&volumeHasName != 0:
+		name, n, ok := readString(data[1:])
+		if !ok {
+			return fmt.Errorf("the volume item name is not null-terminated")
+		}
+		v.Name = name
+		v.Extra = clone(data[1+n:])
+	default:
+		v.Extra = clone(data[1:])
+	}
+	return nil
+}
+
+// MarshalBinary returns the binary representation of the item.
+func (v VolumeItem) MarshalBinary() ([]byte, error) {
+	if v.Type&classGroupMask != volumeGroup {
+		return nil, fmt.Errorf("the volume item has an invalid class type of %#x", v.Type)
+	}
+	data := []byte{v.Type}
+	switch {
+	case v.Type == volumeShellFolder:
+		data = append(data, v.unknown)
+		data = append(data, guid.Bytes(v.ClassID)...)
+	case v.Type&volumeHasName != 0:
+		data = append(data, v.Name...)
+		data = append(data, 0)
+	}
+	return append(data, v.Extra...), nil
+}
@@ -0,0 +1,35 @@
+package winpath
+
+import "strconv"
+
+// Type is the type of a Windows path, as determined by its prefix.
+type Type int
+
+// Windows path types.
+const (
+	Relative        Type = iota // Users\alice
+	Rooted                      // \Users\alice
+	DriveRelative               // C:report.docx
+	DriveAbsolute               // C:\Users\alice
+	UNC                         // \\server\share\report.docx
+	LocalDevice                 // \\.\COM1 or \\.\C:\Users
+	RootLocalDevice             // \\?\C:\Users or \\?\UNC\server\share
+)
+
+var typeNames = [...]string{
+	Relative:        "Relative",
+	Rooted:          "Rooted",
+	DriveRelative:   "DriveRelative",
+	DriveAbsolute:   "DriveAbsolute",
+	UNC:             "UNC",
+	LocalDevice:     "LocalDevice",
+	RootLocalDevice: "RootLocalDevice",
+}
+
+// String returns the name of the path type.
+func (t Type) String() string {
+	if t >= 0 && int(t) < len(typeNames) {
+		return typeNames[t]
+	}
+	return "Type(" + strconv.Itoa(int(t)) + ")"
+}
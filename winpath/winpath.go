@@ -0,0 +1,225 @@
+// Package winpath manipulates Windows file system paths on any operating
+// system.
+//
+// It follows the path syntax of Windows regardless of the operating
+// system it runs on, unlike path/filepath. Both backslashes and forward
+// slashes are accepted as separators, except within paths that begin with
+// \\?\, which Windows passes to the file system without normalization.
+package winpath
+
+import (
+	"strings"
+)
+
+// Separator is the preferred path separator of Windows.
+const Separator = '\\'
+
+// IsSeparator returns true if c is a path separator.
+func IsSeparator(c byte) bool {
+	return c == '\\' || c == '/'
+}
+
+// isBackslash returns true if c is a backslash. It is the only separator
+// recognized within root local device paths.
+func isBackslash(c byte) bool {
+	return c == '\\'
+}
+
+// separatorFunc returns the function that recognizes separators within
+// paths of type t.
+func separatorFunc(t Type) func(byte) bool {
+	if t == RootLocalDevice {
+		return isBackslash
+	}
+	return IsSeparator
+}
+
+// FromSlash returns path with each forward slash replaced by a backslash.
+func FromSlash(path string) string {
+	return strings.ReplaceAll(path, "/", `\`)
+}
+
+// ToSlash returns path with each backslash replaced by a forward slash.
+func ToSlash(path string) string {
+	return strings.ReplaceAll(path, `\`, "/")
+}
+
+// Classify returns the type of path.
+func Classify(path string) Type {
+	switch {
+	case len(path) >= 2 && path[1] == ':' && isLetter(path[0]):
+		if len(path) >= 3 && IsSeparator(path[2]) {
+			return DriveAbsolute
+		}
+		return DriveRelative
+	case len(path) == 0 || !IsSeparator(path[0]):
+		return Relative
+	case strings.HasPrefix(path, `\??\`), strings.HasPrefix(path, `\\?\`), path == `\\?`:
+		return RootLocalDevice
+	case len(path) >= 3 && IsSeparator(path[1]) && (path[2] == '.' || path[2] == '?') && (len(path) == 3 || IsSeparator(path[3])):
+		return LocalDevice
+	case len(path) >= 2 && IsSeparator(path[1]):
+		return UNC
+	default:
+		return Rooted
+	}
+}
+
+// Volume returns the volume at the start of path, if it has one:
+//
+//	C:\Users                  C:
+//	C:report.docx             C:
+//	\\server\share\report     \\server\share
+//	\\.\COM1                  \\.\COM1
+//	\\?\C:\Users              \\?\C:
+//	\\?\UNC\server\share\x    \\?\UNC\server\share
+//
+// Relative and rooted paths have no volume.
+func Volume(path string) string {
+	return path[:volumeLen(path)]
+}
+
+// volumeLen returns the length of the volume at the start of path.
+func volumeLen(path string) int {
+	t := Classify(path)
+	switch t {
+	case DriveAbsolute, DriveRelative:
+		return 2
+	case UNC:
+		return uncLen(path, 2, IsSeparator)
+	case LocalDevice, RootLocalDevice:
+		if len(path) <= 4 {
+			return len(path)
+		}
+		isSep := separatorFunc(t)
+		if len(path) >= 8 && strings.EqualFold(path[4:7], "UNC") && isSep(path[7]) {
+			return uncLen(path, 8, isSep)
+		}
+		for i := 4; i < len(path); i++ {
+			if isSep(path[i]) {
+				return i
+			}
+		}
+		return len(path)
+	default:
+		return 0
+	}
+}
+
+// uncLen returns the length of the server and share names that begin at
+// start within path, along with everything before them.
+func uncLen(path string, start int, isSep func(byte) bool) int {
+	count := 0
+	for i := start; i < len(path); i++ {
+		if isSep(path[i]) {
+			count++
+			if count == 2 {
+				return i
+			}
+		}
+	}
+	return len(path)
+}
+
+// IsAbs returns true if path is absolute. Drive letter paths with a
+// separator, UNC paths and device paths are absolute. Rooted paths such
+// as \Users are relative to the current drive, and are not absolute.
+func IsAbs(path string) bool {
+	switch Classify(path) {
+	case DriveAbsolute, UNC, LocalDevice, RootLocalDevice:
+		return true
+	default:
+		return false
+	}
+}
+
+// Split splits path immediately following its final separator, into a
+// directory and file name. The directory includes the volume. If path
+// has no separator after its volume, the directory is the volume and file
+// is the remainder of the path.
+func Split(path string) (dir, file string) {
+	vol := volumeLen(path)
+	isSep := separatorFunc(Classify(path))
+	i := len(path) - 1
+	for i >= vol && !isSep(path[i]) {
+		i--
+	}
+	return path[:i+1], path[i+1:]
+}
+
+// Join joins any number of path elements with separators and cleans the
+// result. Empty elements are ignored. Join does not create a UNC or
+// device path from elements that do not already form one.
+func Join(elem ...string) string {
+	var b strings.Builder
+	for _, e := range elem {
+		if e == "" {
+			continue
+		}
+		if current := b.String(); current != "" {
+			switch {
+			case IsSeparator(current[len(current)-1]):
+				e = strings.TrimLeft(e, `\/`)
+			case Classify(current) == DriveRelative && len(current) == 2:
+				// A drive letter alone is joined without a separator
+			default:
+				b.WriteByte(Separator)
+			}
+		}
+		b.WriteString(e)
+	}
+	if b.Len() == 0 {
+		return ""
+	}
+	return Clean(b.String())
+}
+
+// Clean returns the shortest path equivalent to path by lexical
+// processing, in the manner of path/filepath.Clean. Forward slashes are
+// replaced with backslashes, repeated separators and "." elements are
+// removed, and ".." elements are applied to the elements before them.
+// A trailing separator is removed unless it follows the volume.
+//
+// Paths that begin with \\?\ are returned unchanged, since Windows does
+// not normalize them.
+func Clean(path string) string {
+	if Classify(path) == RootLocalDevice {
+		return path
+	}
+
+	path = FromSlash(path)
+	vol := Volume(path)
+	rest := path[len(vol):]
+	rooted := rest != "" && rest[0] == Separator
+
+	var elements []string
+	for _, element := range strings.Split(rest, `\`) {
+		switch element {
+		case "", ".":
+		case "..":
+			switch {
+			case len(elements) > 0 && elements[len(elements)-1] != "..":
+				elements = elements[:len(elements)-1]
+			case !rooted:
+				elements = append(elements, "..")
+			}
+		default:
+			elements = append(elements, element)
+		}
+	}
+
+	cleaned := strings.Join(elements, `\`)
+	if rooted {
+		cleaned = `\` + cleaned
+	}
+	if vol == "" && cleaned == "" {
+		return "."
+	}
+	return vol + cleaned
+}
+
+// isLetter returns true if c is an ASCII letter.
+func isLetter(c byte) bool {
+	c |= 0x20
+	return c >= 'a' && c <= 'z'
+}
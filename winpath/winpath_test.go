@@ -0,0 +1,167 @@
+package winpath_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gentlemanautomaton/winshell/winpath"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		Path   string
+		Type   winpath.Type
+		Volume string
+		Abs    bool
+	}{
+		{``, winpath.Relative, ``, false},
+		{`report.docx`, winpath.Relative, ``, false},
+		{`Users\alice`, winpath.Relative, ``, false},
+		{`\Users\alice`, winpath.Rooted, ``, false},
+		{`/Users/alice`, winpath.Rooted, ``, false},
+		{`C:`, winpath.DriveRelative, `C:`, false},
+		{`c:report.docx`, winpath.DriveRelative, `c:`, false},
+		{`C:\`, winpath.DriveAbsolute, `C:`, true},
+		{`C:\Users\alice`, winpath.DriveAbsolute, `C:`, true},
+		{`C:/Users/alice`, winpath.DriveAbsolute, `C:`, true},
+		{`1:\Users`, winpath.Relative, ``, false},
+		{`\\server`, winpath.UNC, `\\server`, true},
+		{`\\server\share`, winpath.UNC, `\\server\share`, true},
+		{`\\server\share\dir\file`, winpath.UNC, `\\server\share`, true},
+		{`//server/share/dir`, winpath.UNC, `//server/share`, true},
+		{`\\.\COM1`, winpath.LocalDevice, `\\.\COM1`, true},
+		{`\\.\C:\Users`, winpath.LocalDevice, `\\.\C:`, true},
+		{`\\.\UNC\server\share\dir`, winpath.LocalDevice, `\\.\UNC\server\share`, true},
+		{`//?/C:/Users`, winpath.LocalDevice, `//?/C:`, true},
+		{`\\.`, winpath.LocalDevice, `\\.`, true},
+		{`\\?\C:\Users`, winpath.RootLocalDevice, `\\?\C:`, true},
+		{`\\?\C:/Users`, winpath.RootLocalDevice, `\\?\C:/Users`, true},
+		{`\\?\UNC\server\share\dir`, winpath.RootLocalDevice, `\\?\UNC\server\share`, true},
+		{`\\?\Volume{3c6bb4a0-0000-0000-0000-100000000000}\Users`, winpath.RootLocalDevice, `\\?\Volume{3c6bb4a0-0000-0000-0000-100000000000}`, true},
+		{`\??\C:\Users`, winpath.RootLocalDevice, `\??\C:`, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Path, func(t *testing.T) {
+			if got := winpath.Classify(test.Path); got != test.Type {
+				t.Errorf("Classify: got %s, want %s", got, test.Type)
+			}
+			if got := winpath.Volume(test.Path); got != test.Volume {
+				t.Errorf("Volume: got %q, want %q", got, test.Volume)
+			}
+			if got := winpath.IsAbs(test.Path); got != test.Abs {
+				t.Errorf("IsAbs: got %t, want %t", got, test.Abs)
+			}
+		})
+	}
+}
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		Path, Dir, File string
+	}{
+		{`report.docx`, ``, `report.docx`},
+		{`C:\Users\alice\report.docx`, `C:\Users\alice\`, `report.docx`},
+		{`C:\Users\alice\`, `C:\Users\alice\`, ``},
+		{`C:/Users/report.docx`, `C:/Users/`, `report.docx`},
+		{`C:report.docx`, `C:`, `report.docx`},
+		{`C:\`, `C:\`, ``},
+		{`\\server\share`, `\\server\share`, ``},
+		{`\\server\share\report.docx`, `\\server\share\`, `report.docx`},
+		{`\\.\COM1`, `\\.\COM1`, ``},
+		{`\\?\C:\dir/report.docx`, `\\?\C:\`, `dir/report.docx`},
+	}
+
+	for _, test := range tests {
+		dir, file := winpath.Split(test.Path)
+		if dir != test.Dir || file != test.File {
+			t.Errorf("Split(%q): got %q, %q, want %q, %q", test.Path, dir, file, test.Dir, test.File)
+		}
+	}
+}
+
+func TestClean(t *testing.T) {
+	tests := []struct {
+		Path, Clean string
+	}{
+		{``, `.`},
+		{`.`, `.`},
+		{`a\.\b\\c\`, `a\b\c`},
+		{`a\..\..\b`, `..\b`},
+		{`\..\a`, `\a`},
+		{`C:`, `C:`},
+		{`C:..\a`, `C:..\a`},
+		{`C:\`, `C:\`},
+		{`C:/Users/./alice/../bob/`, `C:\Users\bob`},
+		{`C:\..`, `C:\`},
+		{`\\server\share`, `\\server\share`},
+		{`\\server\share\..\..\x`, `\\server\share\x`},
+		{`//server/share/dir/`, `\\server\share\dir`},
+		{`\\.\C:\a\..\b`, `\\.\C:\b`},
+		{`\\?\C:\a\..\b\`, `\\?\C:\a\..\b\`},
+	}
+
+	for _, test := range tests {
+		if got := winpath.Clean(test.Path); got != test.Clean {
+			t.Errorf("Clean(%q): got %q, want %q", test.Path, got, test.Clean)
+		}
+	}
+}
+
+func TestJoin(t *testing.T) {
+	tests := []struct {
+		Elements []string
+		Path     string
+	}{
+		{nil, ``},
+		{[]string{``, ``}, ``},
+		{[]string{`a`, `b`}, `a\b`},
+		{[]string{`C:`, `Users`}, `C:Users`},
+		{[]string{`C:\`, `Users`, `alice`}, `C:\Users\alice`},
+		{[]string{`C:\Users\`, `\alice`}, `C:\Users\alice`},
+		{[]string{`\`, `\server\share`}, `\server\share`},
+		{[]string{``, `\\server\share`, `dir`}, `\\server\share\dir`},
+		{[]string{`\\server`, `share`, `..`, `other`}, `\\server\share\other`},
+		{[]string{`\\?\C:\`, `dir`}, `\\?\C:\dir`},
+	}
+
+	for _, test := range tests {
+		if got := winpath.Join(test.Elements...); got != test.Path {
+			t.Errorf("Join(%q): got %q, want %q", test.Elements, got, test.Path)
+		}
+	}
+}
+
+func ExampleClassify() {
+	for _, path := range []string{
+		`report.docx`,
+		`\Users\alice`,
+		`C:report.docx`,
+		`C:\Users\alice`,
+		`\\server\share\report.docx`,
+		`\\.\COM1`,
+		`\\?\UNC\server\share\report.docx`,
+	} {
+		fmt.Printf("%s: %s\n", path, winpath.Classify(path))
+	}
+
+	// Output:
+	// report.docx: Relative
+	// \Users\alice: Rooted
+	// C:report.docx: DriveRelative
+	// C:\Users\alice: DriveAbsolute
+	// \\server\share\report.docx: UNC
+	// \\.\COM1: LocalDevice
+	// \\?\UNC\server\share\report.docx: RootLocalDevice
+}
+
+func ExampleVolume() {
+	fmt.Println(winpath.Volume(`C:\Users\alice`))
+	fmt.Println(winpath.Volume(`\\server\share\report.docx`))
+	fmt.Println(winpath.Volume(`\\?\UNC\server\share\report.docx`))
+
+	// Output:
+	// C:
+	// \\server\share
+	// \\?\UNC\server\share
+}